@@ -24,6 +24,9 @@ func main() {
 	flagSet.BoolVarP(&cfg.CliFlags.LogEnabled, "log", "l", false, "Write log messages to <output path>.log.")
 	flagSet.BoolVarP(&cfg.CliFlags.DebugEnabled, "debug", "p", false, "Print the resulting code to STDOUT as well.")
 	flagSet.BoolVarP(&cfg.CliFlags.DryRun, "dry-run", "d", false, "Perform a dry run without writing files.")
+	flagSet.BoolVar(&cfg.CliFlags.Strict, "strict", false, "Fail when a destination field matches no source.")
+	flagSet.StringVar(&cfg.CliFlags.ReportUnusedSrc, "report-unused-src", "", "Report the source fields left unused, as a warning or an error.")
+	flagSet.Lookup("report-unused-src").NoOptDefVal = "warn"
 
 	if err := flagSet.Parse(os.Args[1:]); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "%+v\n", err)
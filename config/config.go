@@ -25,6 +25,8 @@ flag:
   log_enabled: false
   debug_enabled: false
   dry_run: false
+  strict: false
+  report_unused_src: ""
 `)
 
 type (
@@ -44,7 +46,10 @@ type (
 		LogEnabled   bool   `mapstructure:"log_enabled"`
 		DebugEnabled bool   `mapstructure:"debug_enabled"`
 		DryRun       bool   `mapstructure:"dry_run"`
-		ReceiverType string `mapstructure:"receiver_type"`
+		Strict       bool   `mapstructure:"strict"`
+		// ReportUnusedSrc is "warn" or "error", "" to report only the methods with :warn_unused_src.
+		ReportUnusedSrc string `mapstructure:"report_unused_src"`
+		ReceiverType    string `mapstructure:"receiver_type"`
 	}
 )
 
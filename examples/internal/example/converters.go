@@ -1,5 +1,61 @@
 package example
 
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/structcopy/structcopy-gen/examples/internal/example/dto"
+	"github.com/structcopy/structcopy-gen/examples/internal/example/entity"
+)
+
 func TestConvert(t any) string {
 	return t.(string)
 }
+
+func ScoreToString(score int) string {
+	return strconv.Itoa(score)
+}
+
+func ParseAmount(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func FormatAmount(amount int64) string {
+	return strconv.FormatInt(amount, 10)
+}
+
+func ParseQuantity(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+func FormatQuantity(quantity int) string {
+	return strconv.Itoa(quantity)
+}
+
+func SetDisplayName(dst *dto.UserDTO, src *entity.User) {
+	dst.FullName = src.FullName()
+}
+
+func ValidateOrder(dst *dto.OrderDTO, src *entity.Order) error {
+	if dst.Total < 0 {
+		return fmt.Errorf("order %d: negative total %s", src.ID, src.Total)
+	}
+	return nil
+}
+
+func InLocation(t time.Time, loc *time.Location) time.Time {
+	return t.In(loc)
+}
+
+type IDEncoder interface {
+	Encode(id int64) string
+}
+
+type URLSigner interface {
+	Sign(path string) (string, error)
+}
+
+type Clock interface {
+	Now() time.Time
+}
@@ -0,0 +1,17 @@
+package example
+
+import (
+	"strings"
+
+	"github.com/structcopy/structcopy-gen/examples/internal/example/dto"
+	"github.com/structcopy/structcopy-gen/examples/internal/example/entity"
+)
+
+// AuthorToAuthorDTO is declared here, so structcopy-gen doesn't generate it.
+func (c *documentConverter) AuthorToAuthorDTO(src *entity.Author) (dst *dto.AuthorDTO) {
+	dst = &dto.AuthorDTO{Name: src.Name}
+	if user, domain, ok := strings.Cut(src.Email, "@"); ok && user != "" {
+		dst.Email = user[:1] + "***@" + domain
+	}
+	return
+}
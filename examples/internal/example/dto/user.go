@@ -1,9 +1,160 @@
 package dto
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
 type UserDTO struct {
+	ID        int64
+	CreatedAt time.Time
 	FirstName string
 	LastName  string
 	Email     string
 	FullName  string
 	SkipField string
+	Address   *AddressDTO
+	Company   *CompanyDTO
+	Tags      map[string]string
+	Items     map[string]*ItemDTO
+	Scores    map[string]string
+	Status    string
+	Roles     []string
+	Nicknames []string
+	Favorites []*ItemDTO
+
+	City        string
+	CompanyName string
+}
+
+type AddressDTO struct {
+	Street string
+	City   string
+}
+
+type UserPatchDTO struct {
+	FirstName string
+	LastName  string
+	EMail     *string
+	Age       *int
+	Address   *AddressDTO
+	Status    string
+	Nicknames []string
+}
+
+type ItemDTO struct {
+	Name  string
+	Price int64
+}
+
+type CompanyDTO struct {
+	Name    string
+	Address AddressDTO
+}
+
+type OrderDTO struct {
+	ID        int64
+	Total     int64
+	Lines     []*OrderLineDTO
+	CreatedAt time.Time
+}
+
+type OrderLineDTO struct {
+	Name     string
+	Quantity int
+}
+
+type AccountDTO struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name,omitempty"`
+	Password   string    `json:"password"`
+	Version    string    `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+type ProfileDTO struct {
+	UserId int64
+	URL    string
+	Bio    string
+}
+
+type ShipmentDTO struct {
+	ID       int64
+	Shipping *AddressDTO
+	Billing  AddressDTO
+}
+
+type ProductDTO struct {
+	Name  string
+	Price int64
+	SKU   string
+}
+
+type ContactDTO struct {
+	email string
+	phone string
+	tags  []string
+}
+
+func (c *ContactDTO) SetEmail(email string) error {
+	if !strings.Contains(email, "@") {
+		return fmt.Errorf("invalid email %q", email)
+	}
+	c.email = email
+	return nil
+}
+
+func (c *ContactDTO) SetPhoneNumber(phone string) {
+	c.phone = phone
+}
+
+func (c *ContactDTO) SetTags(tags []string) {
+	c.tags = tags
+}
+
+type CategoryDTO struct {
+	Name     string
+	Children []*CategoryDTO
+}
+
+type DocumentDTO struct {
+	ID       string
+	Title    string
+	URL      string
+	SyncedAt time.Time
+}
+
+type AuthorDTO struct {
+	Name  string
+	Email string
+}
+
+type FolderDTO struct {
+	Name      string
+	Owner     *AuthorDTO
+	Documents []*DocumentDTO
+}
+
+type StatusCode int
+
+const (
+	StatusCodeActive StatusCode = iota + 1
+	StatusCodeInactive
+	StatusCodeDisabled
+)
+
+type Plan string
+
+const (
+	PlanBasic      Plan = "basic"
+	PlanPro        Plan = "pro"
+	PlanEnterprise Plan = "enterprise"
+	PlanUnknown    Plan = "unknown"
+)
+
+type MemberDTO struct {
+	Name   string
+	Status StatusCode
+	Plan   Plan
 }
@@ -1,11 +1,156 @@
 package entity
 
+import (
+	"errors"
+	"time"
+)
+
+type BaseModel struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
 type User struct {
+	BaseModel
 	FirstName string
 	LastName  string
 	EMail     string
+	Address   *Address
+	Company   Company
+	Tags      map[string]string
+	Items     map[string]*Item
+	Scores    map[string]int
+	Status    Status
+	Roles     []Status
+	Nicknames []string
+	Favorites []*Item
+	Age       int
 }
 
 func (u *User) FullName() string {
 	return u.FirstName + " " + u.LastName
 }
+
+type Address struct {
+	Street string
+	City   string
+}
+
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusInactive  Status = "inactive"
+	StatusSuspended Status = "suspended"
+	StatusBanned    Status = "banned"
+)
+
+type Level int
+
+const (
+	LevelBasic Level = iota
+	LevelPro
+	LevelEnterprise
+	LevelLegacy
+)
+
+type Item struct {
+	Name  string
+	Price int64
+}
+
+type Company struct {
+	Name    string
+	Address Address
+}
+
+type Order struct {
+	ID        int64
+	Total     string
+	Lines     []*OrderLine
+	CreatedAt time.Time
+}
+
+type OrderLine struct {
+	Name     string
+	Quantity string
+}
+
+type Account struct {
+	AccountID   int64  `json:"id"`
+	DisplayName string `json:"name"`
+	Password    string `json:"-"`
+}
+
+type Profile struct {
+	UserID     int64
+	ProfileURL string
+	ProfileBio string
+}
+
+type Shipment struct {
+	ID             int64
+	ShippingStreet string
+	ShippingCity   string
+	BillingStreet  string
+	BillingTown    string
+}
+
+type Product struct {
+	name  string
+	price int64
+	sku   string
+}
+
+func NewProduct(name string, price int64, sku string) *Product {
+	return &Product{name: name, price: price, sku: sku}
+}
+
+func (p *Product) Name() string {
+	return p.name
+}
+
+func (p Product) GetPrice() int64 {
+	return p.price
+}
+
+func (p *Product) SKU() (string, error) {
+	if p.sku == "" {
+		return "", errors.New("product has no SKU")
+	}
+	return p.sku, nil
+}
+
+type Contact struct {
+	Email  string
+	Mobile string
+	Tags   []Status
+}
+
+type Category struct {
+	Name     string
+	Children []*Category
+}
+
+type Document struct {
+	ID    int64
+	Title string
+	Path  string
+}
+
+type Author struct {
+	Name  string
+	Email string
+}
+
+type Folder struct {
+	Name      string
+	Owner     *Author
+	Documents []*Document
+}
+
+type Member struct {
+	Name   string
+	Status Status
+	Level  Level
+}
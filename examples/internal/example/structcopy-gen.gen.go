@@ -3,27 +3,138 @@
 package example
 
 import (
+	"time"
+
 	"github.com/structcopy/structcopy-gen/examples/internal/example/dto"
 	"github.com/structcopy/structcopy-gen/examples/internal/example/entity"
 )
 
 func UserToUserDTO(src *entity.User) (dst *dto.UserDTO) {
 	dst = &dto.UserDTO{}
+	dst.ID = src.BaseModel.ID
+	dst.CreatedAt = src.BaseModel.CreatedAt
 	dst.FirstName = src.FirstName
 	dst.LastName = TestConvert(src.LastName)
 	dst.Email = TestConvert(src.EMail)
 	dst.FullName = src.FullName()
 	// skip: dst.SkipField
+	if src.Address != nil {
+		dst.Address = &dto.AddressDTO{}
+		dst.Address.Street = src.Address.Street
+		dst.Address.City = src.Address.City
+	}
+	dst.Company = CompanyToCompanyDTO(&src.Company)
+	if src.Tags != nil {
+		dst.Tags = make(map[string]string, len(src.Tags))
+		for k, v := range src.Tags {
+			dst.Tags[k] = v
+		}
+	}
+	if src.Items != nil {
+		dst.Items = make(map[string]*dto.ItemDTO, len(src.Items))
+		for k, v := range src.Items {
+			if v == nil {
+				dst.Items[k] = nil
+				continue
+			}
+			dst.Items[k] = ItemToItemDTO(v)
+		}
+	}
+	if src.Scores != nil {
+		dst.Scores = make(map[string]string, len(src.Scores))
+		for k, v := range src.Scores {
+			dst.Scores[k] = ScoreToString(v)
+		}
+	}
+	dst.Status = string(src.Status)
+	if src.Roles != nil {
+		dst.Roles = make([]string, len(src.Roles))
+		for i, e := range src.Roles {
+			dst.Roles[i] = string(e)
+		}
+	}
+	if src.Nicknames != nil {
+		dst.Nicknames = make([]string, len(src.Nicknames))
+		copy(dst.Nicknames, src.Nicknames)
+	}
+	if src.Favorites != nil {
+		dst.Favorites = make([]*dto.ItemDTO, len(src.Favorites))
+		for i, e := range src.Favorites {
+			if e == nil {
+				continue
+			}
+			dst.Favorites[i] = ItemToItemDTO(e)
+		}
+	}
+	if src.Address != nil {
+		dst.City = src.Address.City
+	}
+	dst.CompanyName = src.Company.Name
 
 	return
 }
 
 func UserToUserDTORaw(src entity.User) (dst dto.UserDTO) {
+	dst.ID = src.BaseModel.ID
+	dst.CreatedAt = src.BaseModel.CreatedAt
 	dst.FirstName = src.FirstName
 	dst.LastName = src.LastName
 	dst.Email = src.EMail
 	// no match: dst.FullName
 	// skip: dst.SkipField
+	if src.Address != nil {
+		dst.Address = &dto.AddressDTO{}
+		dst.Address.Street = src.Address.Street
+		dst.Address.City = src.Address.City
+	}
+	dst.Company = CompanyToCompanyDTO(&src.Company)
+	if src.Tags != nil {
+		dst.Tags = make(map[string]string, len(src.Tags))
+		for k, v := range src.Tags {
+			dst.Tags[k] = v
+		}
+	}
+	if src.Items != nil {
+		dst.Items = make(map[string]*dto.ItemDTO, len(src.Items))
+		for k, v := range src.Items {
+			if v == nil {
+				dst.Items[k] = nil
+				continue
+			}
+			dst.Items[k] = ItemToItemDTO(v)
+		}
+	}
+	if src.Scores != nil {
+		dst.Scores = make(map[string]string, len(src.Scores))
+		for k, v := range src.Scores {
+			dst.Scores[k] = ScoreToString(v)
+		}
+	}
+	dst.Status = string(src.Status)
+	if src.Roles != nil {
+		dst.Roles = make([]string, len(src.Roles))
+		for i, e := range src.Roles {
+			dst.Roles[i] = string(e)
+		}
+	}
+	if src.Nicknames != nil {
+		dst.Nicknames = make([]string, len(src.Nicknames))
+		copy(dst.Nicknames, src.Nicknames)
+	}
+	if src.Favorites != nil {
+		dst.Favorites = make([]*dto.ItemDTO, len(src.Favorites))
+		for i, e := range src.Favorites {
+			if e == nil {
+				continue
+			}
+			dst.Favorites[i] = ItemToItemDTO(e)
+		}
+	}
+	if src.Address != nil {
+		dst.City = src.Address.City
+	}
+	dst.CompanyName = src.Company.Name
+	SetDisplayName(&dst, &src)
 
 	return
 }
@@ -49,3 +160,377 @@ func UserSliceToUserDTOSliceRaw(src []entity.User) (dst []dto.UserDTO) {
 
 	return
 }
+
+func CompanyToCompanyDTO(src *entity.Company) (dst *dto.CompanyDTO) {
+	dst = &dto.CompanyDTO{}
+	dst.Name = src.Name
+	dst.Address.Street = src.Address.Street
+	dst.Address.City = src.Address.City
+
+	return
+}
+
+func ItemToItemDTO(src *entity.Item) (dst *dto.ItemDTO) {
+	dst = &dto.ItemDTO{}
+	dst.Name = src.Name
+	dst.Price = src.Price
+
+	return
+}
+
+func OrderToOrderDTO(src *entity.Order) (dst *dto.OrderDTO, err error) {
+	dst = &dto.OrderDTO{}
+	dst.ID = src.ID
+	dst.Total, err = ParseAmount(src.Total)
+	if err != nil {
+		return
+	}
+	if src.Lines != nil {
+		dst.Lines = make([]*dto.OrderLineDTO, len(src.Lines))
+		for i, e := range src.Lines {
+			if e == nil {
+				continue
+			}
+			dst.Lines[i], err = OrderLineToOrderLineDTO(e)
+			if err != nil {
+				return
+			}
+		}
+	}
+	dst.CreatedAt = src.CreatedAt
+	err = ValidateOrder(dst, src)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func OrderLineToOrderLineDTO(src *entity.OrderLine) (dst *dto.OrderLineDTO, err error) {
+	dst = &dto.OrderLineDTO{}
+	dst.Name = src.Name
+	dst.Quantity, err = ParseQuantity(src.Quantity)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func CopyItemInto(dst *dto.ItemDTO, src *entity.Item) {
+	dst.Name = src.Name
+	dst.Price = src.Price
+}
+
+func CopyOrderInto(dst *dto.OrderDTO, src entity.Order) (err error) {
+	dst.ID = src.ID
+	dst.Total, err = ParseAmount(src.Total)
+	if err != nil {
+		return
+	}
+	if src.Lines != nil {
+		dst.Lines = make([]*dto.OrderLineDTO, len(src.Lines))
+		for i, e := range src.Lines {
+			if e == nil {
+				continue
+			}
+			dst.Lines[i], err = OrderLineToOrderLineDTO(e)
+			if err != nil {
+				return
+			}
+		}
+	}
+	dst.CreatedAt = src.CreatedAt
+
+	return
+}
+
+func OrderToOrderDTOIn(src *entity.Order, loc *time.Location) (dst *dto.OrderDTO, err error) {
+	dst = &dto.OrderDTO{}
+	dst.ID = src.ID
+	dst.Total, err = ParseAmount(src.Total)
+	if err != nil {
+		return
+	}
+	if src.Lines != nil {
+		dst.Lines = make([]*dto.OrderLineDTO, len(src.Lines))
+		for i, e := range src.Lines {
+			if e == nil {
+				continue
+			}
+			dst.Lines[i], err = OrderLineToOrderLineDTO(e)
+			if err != nil {
+				return
+			}
+		}
+	}
+	dst.CreatedAt = InLocation(src.CreatedAt, loc)
+
+	return
+}
+
+func OrderSliceToOrderDTOSliceIn(src []*entity.Order, loc *time.Location) (dst []*dto.OrderDTO, err error) {
+	if len(src) > 0 {
+		dst = make([]*dto.OrderDTO, len(src))
+		for i, e := range src {
+			dst[i], err = OrderToOrderDTOIn(e, loc)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func AccountToAccountDTO(src *entity.Account) (dst *dto.AccountDTO) {
+	dst = &dto.AccountDTO{}
+	dst.ID = src.AccountID
+	dst.Name = src.DisplayName
+	if dst.Name == "" {
+		dst.Name = "anonymous"
+	}
+	// no match: dst.Password
+	dst.Version = "v1"
+	dst.ExportedAt = time.Now()
+
+	return
+}
+
+func ProfileToProfileDTO(src *entity.Profile) (dst *dto.ProfileDTO) {
+	dst = &dto.ProfileDTO{}
+	dst.UserId = src.UserID
+	dst.URL = src.ProfileURL
+	dst.Bio = src.ProfileBio
+
+	return
+}
+
+func ShipmentToShipmentDTO(src *entity.Shipment) (dst *dto.ShipmentDTO) {
+	dst = &dto.ShipmentDTO{}
+	dst.ID = src.ID
+	dst.Shipping = &dto.AddressDTO{}
+	dst.Shipping.Street = src.ShippingStreet
+	dst.Shipping.City = src.ShippingCity
+	dst.Billing.Street = src.BillingStreet
+	dst.Billing.City = src.BillingTown
+
+	return
+}
+
+func ProductToProductDTO(src *entity.Product) (dst *dto.ProductDTO, err error) {
+	dst = &dto.ProductDTO{}
+	dst.Name = src.Name()
+	dst.Price = src.GetPrice()
+	dst.SKU, err = src.SKU()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func ContactToContactDTO(src *entity.Contact) (dst *dto.ContactDTO, err error) {
+	dst = &dto.ContactDTO{}
+	err = dst.SetEmail(src.Email)
+	if err != nil {
+		return
+	}
+	dst.SetPhoneNumber(src.Mobile)
+	{
+		var value []string
+		if src.Tags != nil {
+			value = make([]string, len(src.Tags))
+			for i, e := range src.Tags {
+				value[i] = string(e)
+			}
+		}
+		dst.SetTags(value)
+	}
+
+	return
+}
+
+func ApplyUserPatch(dst *entity.User, src *dto.UserPatchDTO) {
+	// no match: dst.BaseModel.ID
+	// no match: dst.BaseModel.CreatedAt
+	if src.FirstName != "" {
+		dst.FirstName = src.FirstName
+	}
+	dst.LastName = src.LastName
+	if src.EMail != nil {
+		dst.EMail = *src.EMail
+	}
+	if src.Address != nil {
+		if dst.Address == nil {
+			dst.Address = &entity.Address{}
+		}
+		if src.Address.Street != "" {
+			dst.Address.Street = src.Address.Street
+		}
+		if src.Address.City != "" {
+			dst.Address.City = src.Address.City
+		}
+	}
+	// no match: dst.Company
+	// no match: dst.Tags
+	// no match: dst.Items
+	// no match: dst.Scores
+	if src.Status != "" {
+		dst.Status = entity.Status(src.Status)
+	}
+	// no match: dst.Roles
+	if src.Nicknames != nil {
+		dst.Nicknames = make([]string, len(src.Nicknames))
+		copy(dst.Nicknames, src.Nicknames)
+	}
+	// no match: dst.Favorites
+	if src.Age != nil {
+		dst.Age = *src.Age
+	}
+}
+
+func StatusToCode(src entity.Status) (dst dto.StatusCode) {
+	switch src {
+	case entity.StatusActive:
+		dst = dto.StatusCodeActive
+	case entity.StatusInactive:
+		dst = dto.StatusCodeInactive
+	case entity.StatusSuspended, entity.StatusBanned:
+		dst = dto.StatusCodeDisabled
+	}
+
+	return
+}
+
+func MemberToMemberDTO(src *entity.Member) (dst *dto.MemberDTO) {
+	dst = &dto.MemberDTO{}
+	dst.Name = src.Name
+	dst.Status = StatusToCode(src.Status)
+	switch src.Level {
+	case entity.LevelBasic:
+		dst.Plan = dto.PlanBasic
+	case entity.LevelPro:
+		dst.Plan = dto.PlanPro
+	case entity.LevelEnterprise:
+		dst.Plan = dto.PlanEnterprise
+	default:
+		dst.Plan = dto.PlanUnknown
+	}
+
+	return
+}
+
+func OrderDTOToOrder(src *dto.OrderDTO) (dst *entity.Order, err error) {
+	dst = &entity.Order{}
+	dst.ID = src.ID
+	dst.Total = FormatAmount(src.Total)
+	if src.Lines != nil {
+		dst.Lines = make([]*entity.OrderLine, len(src.Lines))
+		for i, e := range src.Lines {
+			if e == nil {
+				continue
+			}
+			dst.Lines[i], err = OrderLineDTOToOrderLine(e)
+			if err != nil {
+				return
+			}
+		}
+	}
+	dst.CreatedAt = src.CreatedAt
+
+	return
+}
+
+func OrderLineDTOToOrderLine(src *dto.OrderLineDTO) (dst *entity.OrderLine, err error) {
+	dst = &entity.OrderLine{}
+	dst.Name = src.Name
+	dst.Quantity = FormatQuantity(src.Quantity)
+
+	return
+}
+
+var CategoryToCategoryDTO func(src *entity.Category) (dst *dto.CategoryDTO)
+
+var CompanyToDTO = func(src *entity.Company) (dst *dto.CompanyDTO) {
+	dst = &dto.CompanyDTO{}
+	dst.Name = src.Name
+	dst.Address.Street = src.Address.Street
+	dst.Address.City = src.Address.City
+
+	return
+}
+
+var CompaniesToDTOs = func(src []*entity.Company) (dst []*dto.CompanyDTO) {
+	if len(src) > 0 {
+		dst = make([]*dto.CompanyDTO, len(src))
+		for i, e := range src {
+			dst[i] = CompanyToDTO(e)
+		}
+	}
+
+	return
+}
+
+func init() {
+	CategoryToCategoryDTO = func(src *entity.Category) (dst *dto.CategoryDTO) {
+		dst = &dto.CategoryDTO{}
+		dst.Name = src.Name
+		if src.Children != nil {
+			dst.Children = make([]*dto.CategoryDTO, len(src.Children))
+			for i, e := range src.Children {
+				if e == nil {
+					continue
+				}
+				dst.Children[i] = CategoryToCategoryDTO(e)
+			}
+		}
+
+		return
+	}
+}
+
+type documentConverter struct {
+	ids    IDEncoder
+	signer URLSigner
+	clock  Clock
+}
+
+func NewDocumentConverter(ids IDEncoder, signer URLSigner, clock Clock) DocumentConverter {
+	return &documentConverter{ids: ids, signer: signer, clock: clock}
+}
+
+func (c *documentConverter) DocumentToDocumentDTO(src *entity.Document) (dst *dto.DocumentDTO, err error) {
+	dst = &dto.DocumentDTO{}
+	dst.ID = c.ids.Encode(src.ID)
+	dst.Title = src.Title
+	dst.URL, err = c.signer.Sign(src.Path)
+	if err != nil {
+		return
+	}
+	dst.SyncedAt = c.clock.Now()
+
+	return
+}
+
+func (c *documentConverter) FolderToFolderDTO(src *entity.Folder) (dst *dto.FolderDTO, err error) {
+	dst = &dto.FolderDTO{}
+	dst.Name = src.Name
+	if src.Owner != nil {
+		dst.Owner = c.AuthorToAuthorDTO(src.Owner)
+	}
+	if src.Documents != nil {
+		dst.Documents = make([]*dto.DocumentDTO, len(src.Documents))
+		for i, e := range src.Documents {
+			if e == nil {
+				continue
+			}
+			dst.Documents[i], err = c.DocumentToDocumentDTO(e)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
@@ -1,11 +1,15 @@
 package example
 
 import (
+	"time"
+
 	"github.com/structcopy/structcopy-gen/examples/internal/example/dto"
 	"github.com/structcopy/structcopy-gen/examples/internal/example/entity"
 )
 
 // :structcopy-gen
+// :skip_field SkipField
+// :conv Scores ScoreToString
 //
 //go:generate structcopy-gen structcopy-gen.go
 type StructCopyGen interface {
@@ -13,11 +17,14 @@ type StructCopyGen interface {
 	// :match_method FullName FullName()
 	// :conv LastName TestConvert
 	// :conv Email TestConvert
-	// :skip_field SkipField
+	// :match_field City Address.City
+	// :match_field CompanyName Company.Name
 	UserToUserDTO(src *entity.User) (dst *dto.UserDTO)
 
 	// :match_field Email EMail
-	// :skip_field SkipField
+	// :match_field City Address.City
+	// :match_field CompanyName Company.Name
+	// :postprocess SetDisplayName
 	UserToUserDTORaw(src entity.User) (dst dto.UserDTO)
 
 	// :struct_conv UserToUserDTO
@@ -25,4 +32,93 @@ type StructCopyGen interface {
 
 	// :struct_conv UserToUserDTORaw
 	UserSliceToUserDTOSliceRaw(src []entity.User) (dst []dto.UserDTO)
+
+	CompanyToCompanyDTO(src *entity.Company) (dst *dto.CompanyDTO)
+
+	ItemToItemDTO(src *entity.Item) (dst *dto.ItemDTO)
+
+	// :conv Total ParseAmount FormatAmount
+	// :postprocess ValidateOrder
+	// :reverse OrderDTOToOrder
+	OrderToOrderDTO(src *entity.Order) (dst *dto.OrderDTO, err error)
+
+	// :conv Quantity ParseQuantity FormatQuantity
+	// :reverse OrderLineDTOToOrderLine
+	OrderLineToOrderLineDTO(src *entity.OrderLine) (dst *dto.OrderLineDTO, err error)
+
+	CopyItemInto(dst *dto.ItemDTO, src *entity.Item)
+
+	// :conv Total ParseAmount
+	CopyOrderInto(dst *dto.OrderDTO, src entity.Order) error
+
+	// :conv Total ParseAmount
+	// :conv CreatedAt InLocation
+	OrderToOrderDTOIn(src *entity.Order, loc *time.Location) (dst *dto.OrderDTO, err error)
+
+	// :struct_conv OrderToOrderDTOIn
+	OrderSliceToOrderDTOSliceIn(src []*entity.Order, loc *time.Location) (dst []*dto.OrderDTO, err error)
+
+	// :match_rule tag json
+	// :literal Version "v1"
+	// :literal ExportedAt time.Now()
+	// :default Name "anonymous"
+	AccountToAccountDTO(src *entity.Account) (dst *dto.AccountDTO)
+
+	// :name_match acronym
+	// :strip_prefix Profile
+	// :strict
+	// :warn_unused_src error
+	ProfileToProfileDTO(src *entity.Profile) (dst *dto.ProfileDTO)
+
+	// :match_field Billing.City BillingTown
+	ShipmentToShipmentDTO(src *entity.Shipment) (dst *dto.ShipmentDTO)
+
+	// :use_getters
+	ProductToProductDTO(src *entity.Product) (dst *dto.ProductDTO, err error)
+
+	// :use_setters
+	// :match_setter Phone SetPhoneNumber
+	// :match_field Phone Mobile
+	ContactToContactDTO(src *entity.Contact) (dst *dto.ContactDTO, err error)
+
+	// :merge
+	// :merge_zero LastName
+	ApplyUserPatch(dst *entity.User, src *dto.UserPatchDTO)
+
+	// :enum_pair StatusCodeDisabled StatusSuspended
+	// :enum_pair StatusCodeDisabled StatusBanned
+	StatusToCode(src entity.Status) dto.StatusCode
+
+	// :enum_map Plan Level
+	// :enum_default PlanUnknown
+	MemberToMemberDTO(src *entity.Member) (dst *dto.MemberDTO)
+}
+
+// :structcopy-gen
+// :receiver_type f
+type StructCopyFuncs interface {
+	CategoryToCategoryDTO(src *entity.Category) (dst *dto.CategoryDTO)
+
+	CompanyToDTO(src *entity.Company) (dst *dto.CompanyDTO)
+
+	// :struct_conv CompanyToDTO
+	CompaniesToDTOs(src []*entity.Company) (dst []*dto.CompanyDTO)
+}
+
+// :structcopy-gen
+// :receiver_type s
+// :receiver_name documentConverter
+// :receiver_field ids IDEncoder
+// :receiver_field signer URLSigner
+// :receiver_field clock Clock
+type DocumentConverter interface {
+	// :conv ID c.ids.Encode
+	// :match_field URL Path
+	// :conv URL c.signer.Sign
+	// :literal SyncedAt c.clock.Now()
+	DocumentToDocumentDTO(src *entity.Document) (dst *dto.DocumentDTO, err error)
+
+	AuthorToAuthorDTO(src *entity.Author) (dst *dto.AuthorDTO)
+
+	FolderToFolderDTO(src *entity.Folder) (dst *dto.FolderDTO, err error)
 }
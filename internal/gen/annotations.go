@@ -10,8 +10,8 @@ var (
 	// reStructcopygen is a regular expression that matches a notation that
 	// indicates the beginning of a structcopy-gen block.
 	reStructcopygen = regexp.MustCompile(`^\s*//\s*:structcopy-gen\b`)
-	// reLiteral is a regular expression that matches a notation that
-	// indicates the beginning of a literal block.
+	// reLiteral is a regular expression that matches the args of a notation
+	// followed by a Go expression, e.g. ":literal Version "v1"".
 	reLiteral = regexp.MustCompile(`^\s*\S+\s+(.*)$`)
 )
 
@@ -20,13 +20,54 @@ var ValidOpsIntf = map[string]struct{}{
 	"structcopy-gen": {},
 	"receiver_type":  {},
 	"receiver_name":  {},
+	"receiver_field": {},
+	// inherited by the methods
+	"skip_field":      {},
+	"match_field":     {},
+	"match_method":    {},
+	"match_setter":    {},
+	"conv":            {},
+	"literal":         {},
+	"default":         {},
+	"no_promote":      {},
+	"match_rule":      {},
+	"name_match":      {},
+	"strip_prefix":    {},
+	"strip_suffix":    {},
+	"use_getters":     {},
+	"use_setters":     {},
+	"strict":          {},
+	"warn_unused_src": {},
+	"enum_map":        {},
+	"enum_pair":       {},
+	"enum_default":    {},
 }
 
 // ValidOpsMethod is a set of valid conversion option keys for method-level conversion.
 var ValidOpsMethod = map[string]struct{}{
-	"skip_field":   {},
-	"match_field":  {},
-	"match_method": {},
-	"conv":         {},
-	"struct_conv":  {},
+	"skip_field":      {},
+	"match_field":     {},
+	"match_method":    {},
+	"conv":            {},
+	"struct_conv":     {},
+	"no_promote":      {},
+	"preprocess":      {},
+	"postprocess":     {},
+	"reverse":         {},
+	"merge":           {},
+	"merge_zero":      {},
+	"match_rule":      {},
+	"name_match":      {},
+	"strip_prefix":    {},
+	"strip_suffix":    {},
+	"use_getters":     {},
+	"use_setters":     {},
+	"match_setter":    {},
+	"literal":         {},
+	"default":         {},
+	"strict":          {},
+	"warn_unused_src": {},
+	"enum_map":        {},
+	"enum_pair":       {},
+	"enum_default":    {},
 }
@@ -3,7 +3,12 @@ package gen
 import (
 	"errors"
 	"fmt"
+	"go/types"
 	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/samber/lo"
 	"github.com/structcopy/structcopy-gen/pkg/structcopy"
@@ -25,6 +30,13 @@ func (g *Generator) mkMethodAssignments(src structcopy.MethodParam, dst structco
 			return nil, err
 		}
 		assignments = append(assignments, structAssignments...)
+	} else if method.EnumMap {
+		g.logger.Info(fmt.Sprintf("Build copy EnumToEnum for method: %s", method.Name))
+		assignment, err := g.mkEnumAssignment(dst.Name, dst.Typ, src.Name, src.Typ, method)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
 	} else {
 		// skip
 		g.logger.Info(fmt.Sprintf("Skip method: %s", method.Name))
@@ -37,11 +49,104 @@ func (g *Generator) mkStructToStructAssignments(
 	src structcopy.MethodParam,
 	dst structcopy.MethodResult,
 	method structcopy.Method,
+) ([]structcopy.Assignment, error) {
+	return g.mkStructFieldsAssignments(src.Name, src.StructDef, dst.Name, dst.StructDef, "", "", method)
+}
+
+// mkStructFieldsAssignments builds the assignments of all fields of dstStruct
+// from srcStruct. dstPath is the dotted path of dstExpr from the method's
+// destination, used to look up the notations of nested fields. srcPrefix is
+// prepended to the names of the fields looked up in srcStruct, when dstStruct
+// is unflattened from its prefixed fields.
+func (g *Generator) mkStructFieldsAssignments(
+	srcExpr string,
+	srcStruct *structcopy.Struct,
+	dstExpr string,
+	dstStruct *structcopy.Struct,
+	dstPath string,
+	srcPrefix string,
+	method structcopy.Method,
 ) ([]structcopy.Assignment, error) {
 	assignments := make([]structcopy.Assignment, 0)
 
-	for _, field := range dst.StructDef.Fields {
-		assignment, err := g.mkFieldAssignment(field, src, dst, method)
+	dstFields := g.promoteFields(dstStruct, method.NoPromoteMap)
+	srcFields := g.promoteFields(srcStruct, method.NoPromoteMap)
+
+	for _, name := range slices.Sorted(maps.Keys(dstFields.Ambiguous)) {
+		paths := dstFields.Ambiguous[name]
+		if _, ok := method.SkipFieldsMap[dstPath+name]; !ok {
+			err := fmt.Errorf("%s: destination field %s.%s is ambiguous (%s), use :skip_field or :no_promote",
+				method.Name, dstExpr, name, strings.Join(paths, ", "))
+			g.logger.Error("", slog.Any("error", err))
+			return nil, err
+		}
+	}
+
+	allocated := map[string]bool{}
+	setFields := map[string]bool{}
+	for _, field := range dstFields.Fields {
+		setter, setterSig, err := g.findSetter(dstFields.Typ, field.Name, dstPath, method)
+		if err != nil {
+			g.logger.Error("", slog.Any("error", err))
+			return nil, err
+		}
+		if !field.Exported && setter == "" {
+			continue
+		}
+
+		var assignment structcopy.Assignment
+		if setter != "" {
+			setFields[exportedName(field.Name)] = true
+			assignment, err = g.mkSetterAssignment(exportedName(field.Name), setter, setterSig, srcExpr, srcFields, dstExpr, dstPath, srcPrefix, method)
+		} else {
+			// promoted fields are selected through their embedded structs, as the
+			// bare name may be ambiguous with a field of a :no_promote one
+			assignment, err = g.mkFieldAssignment(field, srcExpr, srcFields, fmt.Sprintf("%s.%s", dstExpr, field.Path), dstPath, srcPrefix, method)
+		}
+		if err != nil {
+			g.logger.Error("", slog.Any("error", err))
+			return nil, err
+		}
+
+		switch assignment.(type) {
+		case *structcopy.SkipField, *structcopy.NoMatchField:
+		default:
+			// allocate the pointer embedded structs the field is promoted through
+			for _, ptr := range field.EmbeddedPtrs {
+				if allocated[ptr] {
+					continue
+				}
+				allocated[ptr] = true
+				var alloc structcopy.Assignment = &structcopy.SimpleField{
+					LHS: fmt.Sprintf("%s.%s", dstExpr, ptr),
+					RHS: fmt.Sprintf("&%s{}", g.typeString(dstFields.EmbeddedPtrs[ptr])),
+				}
+				// a destination given as an argument may already hold them, along
+				// with the values of the fields which aren't copied
+				if method.Merge || method.DstVarStyle == structcopy.DstVarArg {
+					alloc = &structcopy.NestStruct{
+						CondExpr: fmt.Sprintf("%s.%s == nil", dstExpr, ptr),
+						Contents: []structcopy.Assignment{alloc},
+					}
+				}
+				assignments = append(assignments, alloc)
+			}
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	// setters given by :match_setter without a field of the same name
+	for _, name := range slices.Sorted(maps.Keys(method.MatchSettersMap)) {
+		name, ok := strings.CutPrefix(name, dstPath)
+		if !ok || strings.Contains(name, ".") || setFields[name] {
+			continue
+		}
+		setter, setterSig, err := g.findSetter(dstFields.Typ, name, dstPath, method)
+		if err != nil {
+			g.logger.Error("", slog.Any("error", err))
+			return nil, err
+		}
+		assignment, err := g.mkSetterAssignment(name, setter, setterSig, srcExpr, srcFields, dstExpr, dstPath, srcPrefix, method)
 		if err != nil {
 			g.logger.Error("", slog.Any("error", err))
 			return nil, err
@@ -52,10 +157,207 @@ func (g *Generator) mkStructToStructAssignments(
 	return assignments, nil
 }
 
+// findSetter returns the setter of the destination field name given by
+// :match_setter, or named "Set<Field>" with :use_setters. A setter takes the
+// value and returns nothing or an error. It returns "" when the field isn't
+// set by a setter.
+func (g *Generator) findSetter(dstTyp types.Type, name string, dstPath string, method structcopy.Method) (string, *types.Signature, error) {
+	name = exportedName(name)
+
+	setter, explicit := method.MatchSettersMap[dstPath+name]
+	if !explicit {
+		if !method.UseSetters {
+			return "", nil, nil
+		}
+		setter = "Set" + name
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(dstTyp, true, g.pkg.Types, setter)
+	fn, ok := obj.(*types.Func)
+	if ok && (fn.Exported() || fn.Pkg() == g.pkg.Types) {
+		sig := fn.Type().(*types.Signature)
+		validResults := sig.Results().Len() == 0 || (sig.Results().Len() == 1 && isErrorType(sig.Results().At(0).Type()))
+		if sig.Params().Len() == 1 && !sig.Variadic() && validResults {
+			return setter, sig, nil
+		}
+	}
+	if explicit {
+		return "", nil, fmt.Errorf("%s: %s is not a setter of %s, it must take a value and return nothing or an error",
+			method.Name, setter, g.typeString(dstTyp))
+	}
+	return "", nil, nil
+}
+
+// mkSetterAssignment builds the call of the setter of the destination field
+// name. The value is built like the field's, into a variable when it takes
+// more than an expression.
+func (g *Generator) mkSetterAssignment(
+	name string,
+	setter string,
+	sig *types.Signature,
+	srcExpr string,
+	srcFields *structFields,
+	dstExpr string,
+	dstPath string,
+	srcPrefix string,
+	method structcopy.Method,
+) (structcopy.Assignment, error) {
+	valueTyp := sig.Params().At(0).Type()
+	field := structcopy.Field{
+		Name:     name,
+		Path:     name,
+		Type:     g.typeString(valueTyp),
+		FullType: g.typeString(valueTyp),
+		Exported: true,
+		Typ:      valueTyp,
+	}
+	// nested setters get a variable of their own
+	tmp := "value"
+	if depth := strings.Count(dstPath, "."); depth > 0 {
+		tmp = fmt.Sprintf("value%d", depth)
+	}
+
+	assignment, err := g.mkFieldAssignment(field, srcExpr, srcFields, tmp, dstPath, srcPrefix, method)
+	if err != nil {
+		return nil, err
+	}
+	// in merge mode, the setter is called only when the source value is set,
+	// and never with the value of a nil source pointer
+	cond := ""
+	if merged, ok := assignment.(*structcopy.NestStruct); ok && merged.CondExpr != "" && len(merged.Contents) == 1 {
+		cond, assignment = merged.CondExpr, merged.Contents[0]
+	} else if checked, ok := assignment.(*structcopy.NestStruct); ok && checked.NullCheckExpr != "" &&
+		checked.InitExpr == "" && len(checked.Contents) == 1 {
+		cond, assignment = checked.NullCheckExpr+" != nil", checked.Contents[0]
+	} else if method.Merge && !method.MergeZeroMap[dstPath+name] {
+		// slices and maps are copied only when not nil, the setter must not be called with nil either
+		switch a := assignment.(type) {
+		case *structcopy.MapAssignment:
+			cond = a.RHS + " != nil"
+		case *structcopy.MapConvertLoopAssignment:
+			cond = a.RHS + " != nil"
+		case *structcopy.SliceAssignment:
+			cond = a.RHS + " != nil"
+		case *structcopy.SliceLoopAssignment:
+			cond = a.RHS + " != nil"
+		case *structcopy.SliceTypecastAssignment:
+			cond = a.RHS + " != nil"
+		case *structcopy.SliceConvertLoopAssignment:
+			cond = a.RHS + " != nil"
+		}
+	}
+
+	setterField := &structcopy.SetterField{
+		Receiver: dstExpr,
+		Setter:   setter,
+		Var:      tmp,
+		Typ:      field.FullType,
+		Error:    retErrorOnly(sig),
+	}
+	switch a := assignment.(type) {
+	case *structcopy.SkipField:
+		return &structcopy.SkipField{LHS: fmt.Sprintf("%s.%s()", dstExpr, setter)}, nil
+	case *structcopy.NoMatchField:
+		return &structcopy.NoMatchField{LHS: fmt.Sprintf("%s.%s()", dstExpr, setter)}, nil
+	case *structcopy.SimpleField:
+		if !a.Error {
+			setterField.Value = a.RHS
+		}
+	case *structcopy.ConvertField:
+		if !a.Error {
+			setterField.Value = callExpr(a.Convert, a.RHS, a.Args)
+		}
+	case *structcopy.MatchMethodField:
+		if !a.Error {
+			setterField.Value = fmt.Sprintf("%s.%s", a.RContainer, a.MatchMethod)
+		}
+	}
+	if setterField.Value == "" {
+		setterField.Contents = []structcopy.Assignment{assignment}
+	}
+	if cond != "" {
+		return &structcopy.NestStruct{
+			CondExpr: cond,
+			Contents: []structcopy.Assignment{setterField},
+		}, nil
+	}
+	return setterField, nil
+}
+
+// mkFieldAssignment builds the assignment of the destination field to lhs,
+// falling back to the :default expression of the field when it's left to its
+// zero value.
 func (g *Generator) mkFieldAssignment(
 	field structcopy.Field,
-	src structcopy.MethodParam,
-	dst structcopy.MethodResult,
+	srcExpr string,
+	srcFields *structFields,
+	lhs string,
+	dstPath string,
+	srcPrefix string,
+	method structcopy.Method,
+) (structcopy.Assignment, error) {
+	assignment, err := g.mkFieldCopyAssignment(field, srcExpr, srcFields, lhs, dstPath, srcPrefix, method)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldPath := dstPath + field.Name
+	defaultExpr, ok := method.DefaultsMap[fieldPath]
+	if !ok {
+		return assignment, nil
+	}
+	if err := g.checkExpr(defaultExpr, field.Typ); err != nil {
+		return nil, fmt.Errorf("%s: default of %s: %w", method.Name, fieldPath, err)
+	}
+
+	// a destination given as an argument may already hold a value, which
+	// the default must not overwrite
+	keepValue := method.Merge || method.DstVarStyle == structcopy.DstVarArg
+	switch assignment.(type) {
+	case *structcopy.SkipField:
+		return assignment, nil
+	case *structcopy.NoMatchField:
+		if !keepValue {
+			return &structcopy.SimpleField{
+				LHS: lhs,
+				RHS: defaultExpr,
+			}, nil
+		}
+	}
+
+	isZero, ok := g.zeroCond(lhs, field.Typ, false)
+	if !ok {
+		return nil, fmt.Errorf("%s: default of %s: %s can't be compared to its zero value",
+			method.Name, fieldPath, g.typeString(field.Typ))
+	}
+	if _, ok := assignment.(*structcopy.NoMatchField); ok {
+		return &structcopy.DefaultField{
+			LHS:      lhs,
+			CondExpr: isZero,
+			Value:    defaultExpr,
+		}, nil
+	}
+	return &structcopy.NestStruct{
+		Contents: []structcopy.Assignment{
+			assignment,
+			&structcopy.DefaultField{
+				LHS:      lhs,
+				CondExpr: isZero,
+				Value:    defaultExpr,
+			},
+		},
+	}, nil
+}
+
+// mkFieldCopyAssignment builds the assignment of the destination field to
+// lhs from the source field paired with it.
+func (g *Generator) mkFieldCopyAssignment(
+	field structcopy.Field,
+	srcExpr string,
+	srcFields *structFields,
+	lhs string,
+	dstPath string,
+	srcPrefix string,
 	method structcopy.Method,
 ) (structcopy.Assignment, error) {
 	skipFieldsMap := method.SkipFieldsMap
@@ -63,67 +365,948 @@ func (g *Generator) mkFieldAssignment(
 	convertersMap := method.ConvertersMap
 	matchMethodsMap := method.MatchMethodsMap
 
-	srcFieldName := field.Name
+	fieldPath := dstPath + field.Name
+	srcFieldName := srcPrefix + field.Name
 
 	dstSkipField := false
-	_, ok := skipFieldsMap[field.Name]
+	_, ok := skipFieldsMap[fieldPath]
 	if ok {
 		dstSkipField = true
 	}
 
-	matchSrcFieldName, ok := matchFieldsMap[field.Name]
+	matchSrcFieldName, ok := matchFieldsMap[fieldPath]
 	if ok {
 		srcFieldName = matchSrcFieldName
 	}
 
+	enumSrcFieldName, enumMap := method.EnumMapsMap[fieldPath]
+	if enumMap {
+		matchSrcFieldName = enumSrcFieldName
+		srcFieldName = enumSrcFieldName
+	}
+
 	srcConverterFunc := ""
-	converter, ok := convertersMap[field.Name]
+	converter, ok := convertersMap[fieldPath]
 	if ok {
 		srcConverterFunc = converter
 	}
 
 	srcMatchMethod := ""
-	matchMethod, ok := matchMethodsMap[field.Name]
+	matchMethod, ok := matchMethodsMap[fieldPath]
 	if ok {
 		srcMatchMethod = matchMethod
 	}
 
-	matchSrcField := lo.ContainsBy(src.StructDef.Fields, func(fi structcopy.Field) bool {
-		return fi.Name == field.Name
-	})
-
-	lhs := fmt.Sprintf("%s.%s", dst.Name, field.Name)
-	rhs := fmt.Sprintf("%s.%s", src.Name, field.Name)
-	if srcFieldName != "" {
-		rhs = fmt.Sprintf("%s.%s", src.Name, srcFieldName)
-	}
-
 	if dstSkipField {
 		return &structcopy.SkipField{
 			LHS: lhs,
 		}, nil
+	} else if literal, ok := method.LiteralsMap[fieldPath]; ok {
+		if err := g.checkExpr(literal, field.Typ); err != nil {
+			return nil, fmt.Errorf("%s: literal of %s: %w", method.Name, fieldPath, err)
+		}
+		return &structcopy.SimpleField{
+			LHS: lhs,
+			RHS: literal,
+		}, nil
 	} else if srcMatchMethod != "" {
-		return &structcopy.MatchMethodField{
+		g.useSrcField(srcExpr, srcMatchMethod)
+		matchMethodField := &structcopy.MatchMethodField{
 			LHS:         lhs,
-			RContainer:  src.Name,
+			RContainer:  srcExpr,
 			MatchMethod: srcMatchMethod,
-		}, nil
-	} else if srcMatchMethod == "" && matchSrcFieldName == "" && !matchSrcField {
+		}
+		if sig := g.lookupMethod(srcFields.Typ, strings.TrimSuffix(srcMatchMethod, "()")); sig != nil {
+			matchMethodField.Error = retError(sig)
+		}
+		return matchMethodField, nil
+	}
+
+	srcField, matchSrcField, err := g.findSrcField(field, fieldPath, matchSrcFieldName, srcExpr, srcFields, srcPrefix, method)
+	if err != nil {
+		return nil, err
+	}
+	if matchSrcField {
+		srcFieldName = srcField.Name
+		g.useSrcField(srcExpr, srcFieldName)
+	}
+
+	if matchSrcFieldName == "" && !matchSrcField && method.UseGetters && method.MatchRule != structcopy.MatchRuleNone {
+		getter, sig := g.findGetter(srcFields.Typ, srcFieldName)
+		if getter != "" {
+			resultTyp := sig.Results().At(0).Type()
+			if retError(sig) {
+				// the value and the error can't be passed on to a conversion
+				if types.AssignableTo(resultTyp, field.Typ) {
+					return &structcopy.MatchMethodField{
+						LHS:         lhs,
+						RContainer:  srcExpr,
+						MatchMethod: getter + "()",
+						Error:       true,
+					}, nil
+				}
+			} else {
+				srcField = structcopy.Field{Name: getter + "()", Typ: resultTyp}
+				srcFieldName = srcField.Name
+				matchSrcField = true
+			}
+		}
+	}
+	rhs := fmt.Sprintf("%s.%s", srcExpr, srcFieldName)
+	if matchSrcField && srcField.Path != "" {
+		rhs = fmt.Sprintf("%s.%s", srcExpr, srcField.Path)
+	}
+
+	var assignment structcopy.Assignment
+	if matchSrcFieldName == "" && !matchSrcField {
+		unflatten, err := g.mkUnflattenAssignment(field, srcExpr, srcFields, lhs, fieldPath, srcFieldName, method)
+		if err != nil {
+			return nil, err
+		}
+		if unflatten != nil {
+			return unflatten, nil
+		}
 		return &structcopy.NoMatchField{
 			LHS: lhs,
 		}, nil
+	} else if enumMap {
+		if !matchSrcField {
+			return nil, fmt.Errorf("%s: enum_map of %s: source field %s.%s is not found", method.Name, fieldPath, srcExpr, srcFieldName)
+		}
+		assignment, err = g.mkEnumAssignment(lhs, field.Typ, rhs, srcField.Typ, method)
+		if err != nil {
+			return nil, err
+		}
 	} else if srcConverterFunc != "" {
-		return &structcopy.ConvertField{
-			LHS:     lhs,
-			RHS:     rhs,
-			Convert: srcConverterFunc,
-		}, nil
+		assignment, err = g.mkConvertAssignment(lhs, field.Typ, rhs, srcField.Typ, srcConverterFunc, method)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", method.Name, err)
+		}
+	} else if matchSrcField {
+		assignment, err = g.mkValueAssignment(lhs, field.Typ, rhs, srcField.Typ, fieldPath+".", method)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		return &structcopy.SimpleField{
 			LHS: lhs,
 			RHS: rhs,
 		}, nil
 	}
+
+	// in merge mode, the field is overwritten only by a set source value
+	if method.Merge && !method.MergeZeroMap[fieldPath] {
+		assignment, err = g.mkMergeAssignment(assignment, rhs, srcField.Typ, fieldPath, method)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// fields promoted through pointer embedded structs are read only when set
+	for i := len(srcField.EmbeddedPtrs) - 1; i >= 0; i-- {
+		assignment = &structcopy.NestStruct{
+			NullCheckExpr: fmt.Sprintf("%s.%s", srcExpr, srcField.EmbeddedPtrs[i]),
+			Contents:      []structcopy.Assignment{assignment},
+		}
+	}
+
+	return assignment, nil
+}
+
+// mkMergeAssignment makes assignment of the source value rhs conditional on
+// the value being set, i.e. not zero. Nested structs are already merged field
+// by field, and slices and maps are already copied only when not nil.
+func (g *Generator) mkMergeAssignment(
+	assignment structcopy.Assignment,
+	rhs string,
+	srcTyp types.Type,
+	fieldPath string,
+	method structcopy.Method,
+) (structcopy.Assignment, error) {
+	switch assignment.(type) {
+	case *structcopy.NestStruct,
+		*structcopy.MapAssignment, *structcopy.MapConvertLoopAssignment,
+		*structcopy.SliceAssignment, *structcopy.SliceLoopAssignment,
+		*structcopy.SliceTypecastAssignment, *structcopy.SliceConvertLoopAssignment:
+		return assignment, nil
+	}
+
+	isSet, ok := g.zeroCond(rhs, srcTyp, true)
+	if !ok {
+		return nil, fmt.Errorf("%s: merge of %s: %s can't be compared to its zero value, use :merge_zero",
+			method.Name, fieldPath, g.typeString(srcTyp))
+	}
+	return &structcopy.NestStruct{
+		CondExpr: isSet,
+		Contents: []structcopy.Assignment{assignment},
+	}, nil
+}
+
+// mkUnflattenAssignment builds the nested destination struct field from the
+// flat source fields prefixed by its name, e.g. dst.Shipping.City from
+// src.ShippingCity, and from the notations given on its dotted path, e.g.
+// ":match_field Shipping.Zip PostCode". It returns nil when field isn't a
+// struct or none of its fields is assigned.
+func (g *Generator) mkUnflattenAssignment(
+	field structcopy.Field,
+	srcExpr string,
+	srcFields *structFields,
+	lhs string,
+	fieldPath string,
+	srcPrefix string,
+	method structcopy.Method,
+) (structcopy.Assignment, error) {
+	dstStruct := g.lookupStruct(field.Typ)
+	srcStruct := g.lookupStruct(srcFields.Typ)
+	if dstStruct == nil || srcStruct == nil {
+		return nil, nil
+	}
+
+	hasNotation := false
+	for _, m := range []map[string]string{method.MatchFieldsMap, method.MatchMethodsMap, method.ConvertersMap} {
+		for key := range m {
+			hasNotation = hasNotation || strings.HasPrefix(key, fieldPath+".")
+		}
+	}
+	hasPrefixedField := method.MatchRule != structcopy.MatchRuleNone &&
+		lo.ContainsBy(srcFields.Fields, func(fi structcopy.Field) bool {
+			return fi.Exported && len(fi.Name) > len(srcPrefix) &&
+				strings.HasPrefix(strings.ToLower(fi.Name), strings.ToLower(srcPrefix))
+		})
+	if !hasNotation && !hasPrefixedField {
+		return nil, nil
+	}
+
+	contents, err := g.mkStructFieldsAssignments(srcExpr, srcStruct, lhs, dstStruct, fieldPath+".", srcPrefix, method)
+	if err != nil {
+		return nil, err
+	}
+	assigned := lo.ContainsBy(contents, func(a structcopy.Assignment) bool {
+		switch a.(type) {
+		case *structcopy.SkipField, *structcopy.NoMatchField:
+			return false
+		}
+		return true
+	})
+	if !assigned {
+		return nil, nil
+	}
+
+	nestStruct := &structcopy.NestStruct{
+		Contents: contents,
+	}
+	if base, isPtr := derefType(field.Typ); isPtr {
+		nestStruct.InitExpr = g.allocExpr(lhs, base, method)
+	}
+	return nestStruct, nil
+}
+
+// findGetter looks up the getter of the field name in the method set of the
+// source type t, named either after the field or prefixed by "Get", e.g.
+// Name() or GetName(). The getter takes no argument and returns the value,
+// optionally followed by an error. It returns "" when there is no such getter.
+func (g *Generator) findGetter(t types.Type, name string) (string, *types.Signature) {
+	for _, getter := range []string{name, "Get" + name} {
+		obj, _, _ := types.LookupFieldOrMethod(t, true, g.pkg.Types, getter)
+		fn, ok := obj.(*types.Func)
+		if !ok || (!fn.Exported() && fn.Pkg() != g.pkg.Types) {
+			continue
+		}
+		sig := fn.Type().(*types.Signature)
+		if sig.Params().Len() != 0 || sig.Variadic() {
+			continue
+		}
+		if (sig.Results().Len() == 1 && !isErrorType(sig.Results().At(0).Type())) || retError(sig) {
+			return getter, sig
+		}
+	}
+	return "", nil
+}
+
+// useSrcField records that the source field read by the path or method call
+// name, e.g. "Address.City" or "Status.String()", is used.
+func (g *Generator) useSrcField(srcExpr string, name string) {
+	g.usedSrcFields[srcExpr+"."+strings.Split(name, ".")[0]] = true
+}
+
+// unusedSrcFields returns the exported fields of the source struct of the
+// method which none of its assignments reads.
+func (g *Generator) unusedSrcFields(method structcopy.Method) []string {
+	src := method.FirstParam
+	if src.IsSlice || src.StructDef == nil {
+		return nil
+	}
+
+	var unused []string
+	for _, field := range g.promoteFields(src.StructDef, method.NoPromoteMap).Fields {
+		name := src.Name + "." + field.Name
+		if field.Exported && !g.usedSrcFields[name] {
+			unused = append(unused, name)
+		}
+	}
+	return unused
+}
+
+// findSrcField returns the source field paired with the destination field
+// following the match rule of the method, or the source field named by
+// :match_field when matchName is set.
+func (g *Generator) findSrcField(
+	field structcopy.Field,
+	fieldPath string,
+	matchName string,
+	srcExpr string,
+	srcFields *structFields,
+	srcPrefix string,
+	method structcopy.Method,
+) (structcopy.Field, bool, error) {
+	byName := func(name string) (structcopy.Field, bool, error) {
+		if paths, ok := srcFields.Ambiguous[name]; ok {
+			return structcopy.Field{}, false, fmt.Errorf("%s: source field %s.%s is ambiguous (%s), use :match_field or :no_promote",
+				method.Name, srcExpr, name, strings.Join(paths, ", "))
+		}
+		srcField, ok := lo.Find(srcFields.Fields, func(fi structcopy.Field) bool {
+			return fi.Exported && fi.Name == name
+		})
+		return srcField, ok, nil
+	}
+
+	if matchName != "" {
+		if strings.Contains(matchName, ".") {
+			return g.findSrcFieldByPath(matchName, srcExpr, srcFields, method)
+		}
+		return byName(matchName)
+	}
+
+	rule := method.MatchRule
+	if srcPrefix != "" && rule == structcopy.MatchRuleTag {
+		// unflattened fields are paired by their prefixed name
+		rule = structcopy.MatchRuleName
+	}
+
+	switch rule {
+	case structcopy.MatchRuleNone:
+		// only the fields given a converter are paired by name
+		if _, ok := method.ConvertersMap[fieldPath]; ok {
+			return byName(srcPrefix + field.Name)
+		}
+		return structcopy.Field{}, false, nil
+	case structcopy.MatchRuleTag:
+		key := tagName(field, method.MatchTagKey)
+		if key == "" {
+			return structcopy.Field{}, false, nil
+		}
+		srcFieldsByTag := lo.Filter(srcFields.Fields, func(fi structcopy.Field, _ int) bool {
+			return fi.Exported && tagName(fi, method.MatchTagKey) == key
+		})
+		if len(srcFieldsByTag) > 1 {
+			return structcopy.Field{}, false, fmt.Errorf("%s: source fields %s are ambiguous for tag %s:%q, use :match_field",
+				method.Name, strings.Join(lo.Map(srcFieldsByTag, func(fi structcopy.Field, _ int) string { return srcExpr + "." + fi.Path }), ", "),
+				method.MatchTagKey, key)
+		}
+		if len(srcFieldsByTag) == 0 {
+			return structcopy.Field{}, false, nil
+		}
+		return srcFieldsByTag[0], true, nil
+	default:
+		if (method.NameMatch == "" || method.NameMatch == structcopy.NameMatchExact) &&
+			len(method.StripPrefixes) == 0 && len(method.StripSuffixes) == 0 {
+			return byName(srcPrefix + field.Name)
+		}
+		return g.findSrcFieldByNormalizedName(srcPrefix+field.Name, srcExpr, srcFields, method)
+	}
+}
+
+// findSrcFieldByPath resolves a dotted source path given by :match_field, e.g.
+// "Address.City". The returned field is named after the path, and holds the
+// pointer fields along the path in EmbeddedPtrs, so that they are checked for
+// nil before the field is read.
+func (g *Generator) findSrcFieldByPath(
+	path string,
+	srcExpr string,
+	srcFields *structFields,
+	method structcopy.Method,
+) (structcopy.Field, bool, error) {
+	var ptrs []string
+	fields := srcFields
+	prefix := ""
+	// selector is the path through the embedded structs, e.g. "Base.Address."
+	selector := ""
+	var field structcopy.Field
+
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		if paths, ok := fields.Ambiguous[name]; ok {
+			return structcopy.Field{}, false, fmt.Errorf("%s: source field %s.%s%s is ambiguous (%s)",
+				method.Name, srcExpr, prefix, name, strings.Join(paths, ", "))
+		}
+		found, ok := lo.Find(fields.Fields, func(fi structcopy.Field) bool {
+			return fi.Exported && fi.Name == name
+		})
+		if !ok {
+			return structcopy.Field{}, false, fmt.Errorf("%s: source field %s.%s%s is not found",
+				method.Name, srcExpr, prefix, name)
+		}
+		for _, ptr := range found.EmbeddedPtrs {
+			ptrs = append(ptrs, selector+ptr)
+		}
+		field = found
+
+		if i == len(names)-1 {
+			break
+		}
+		st := g.lookupStruct(found.Typ)
+		if st == nil {
+			return structcopy.Field{}, false, fmt.Errorf("%s: source field %s.%s%s is not a struct",
+				method.Name, srcExpr, prefix, name)
+		}
+		if _, isPtr := derefType(found.Typ); isPtr {
+			ptrs = append(ptrs, selector+found.Path)
+		}
+		prefix += name + "."
+		selector += found.Path + "."
+		fields = g.promoteFields(st, method.NoPromoteMap)
+	}
+
+	field.Name = path
+	field.Path = selector + field.Path
+	field.EmbeddedPtrs = ptrs
+	return field, true, nil
+}
+
+// findSrcFieldByNormalizedName returns the source field whose name normalizes
+// to the same key as the destination field. It fails when several source
+// fields do.
+func (g *Generator) findSrcFieldByNormalizedName(
+	name string,
+	srcExpr string,
+	srcFields *structFields,
+	method structcopy.Method,
+) (structcopy.Field, bool, error) {
+	key := normalizeName(name, method)
+
+	for _, name := range slices.Sorted(maps.Keys(srcFields.Ambiguous)) {
+		if normalizeName(name, method) == key {
+			return structcopy.Field{}, false, fmt.Errorf("%s: source field %s.%s is ambiguous (%s), use :match_field or :no_promote",
+				method.Name, srcExpr, name, strings.Join(srcFields.Ambiguous[name], ", "))
+		}
+	}
+
+	matches := lo.Filter(srcFields.Fields, func(fi structcopy.Field, _ int) bool {
+		return fi.Exported && normalizeName(fi.Name, method) == key
+	})
+	if len(matches) > 1 {
+		return structcopy.Field{}, false, fmt.Errorf("%s: source fields %s are ambiguous for %s once normalized to %q, use :match_field",
+			method.Name, strings.Join(lo.Map(matches, func(fi structcopy.Field, _ int) string { return srcExpr + "." + fi.Path }), ", "),
+			name, key)
+	}
+	if len(matches) == 0 {
+		return structcopy.Field{}, false, nil
+	}
+	return matches[0], true, nil
+}
+
+// tagName returns the name given to field by the struct tag key, e.g. "id"
+// for `json:"id,omitempty"`. Fields without the tag are named after the
+// field, and fields ignored with "-" have no name.
+func tagName(field structcopy.Field, key string) string {
+	value, ok := reflect.StructTag(field.Tag).Lookup(key)
+	if !ok {
+		return field.Name
+	}
+	name, _, _ := strings.Cut(value, ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// mkValueAssignment builds the assignment of rhs of type srcTyp to lhs of
+// type dstTyp. Maps and slices are copied into new ones, and named types
+// sharing the same basic underlying type are typecast. Struct and pointer-to-struct
+// values of different types are converted by another method of the interface
+// when one matches, or inline field by field otherwise. Other values of
+// different types can't be converted.
+func (g *Generator) mkValueAssignment(
+	lhs string,
+	dstTyp types.Type,
+	rhs string,
+	srcTyp types.Type,
+	dstPath string,
+	method structcopy.Method,
+) (structcopy.Assignment, error) {
+	dstMap, dstIsMap := dstTyp.Underlying().(*types.Map)
+	srcMap, srcIsMap := srcTyp.Underlying().(*types.Map)
+	if dstIsMap && srcIsMap {
+		return g.mkMapAssignment(lhs, dstTyp, dstMap, rhs, srcMap, method)
+	}
+
+	dstSlice, dstIsSlice := dstTyp.Underlying().(*types.Slice)
+	srcSlice, srcIsSlice := srcTyp.Underlying().(*types.Slice)
+	if dstIsSlice && srcIsSlice {
+		return g.mkSliceAssignment(lhs, dstTyp, dstSlice, rhs, srcSlice, method)
+	}
+
+	// enums are mapped by another method of the interface, or inline as with
+	// :enum_map, rather than typecast
+	if !types.AssignableTo(srcTyp, dstTyp) && g.isEnum(dstTyp) && g.isEnum(srcTyp) {
+		assignment, err := g.mkMethodCallAssignment(lhs, dstTyp, rhs, srcTyp, method)
+		if err != nil || assignment != nil {
+			return assignment, err
+		}
+		return g.mkEnumAssignment(lhs, dstTyp, rhs, srcTyp, method)
+	}
+
+	// optional values, e.g. a *string copied into a string, are dereferenced when set
+	if srcElem, srcPtr := derefType(srcTyp); srcPtr && !types.AssignableTo(srcTyp, dstTyp) && g.lookupStruct(srcElem) == nil {
+		if _, dstPtr := derefType(dstTyp); !dstPtr {
+			assignment, err := g.mkValueAssignment(lhs, dstTyp, "*"+rhs, srcElem, dstPath, method)
+			if err != nil {
+				return nil, err
+			}
+			return &structcopy.NestStruct{
+				NullCheckExpr: rhs,
+				Contents:      []structcopy.Assignment{assignment},
+			}, nil
+		}
+	}
+
+	if !types.AssignableTo(srcTyp, dstTyp) && isTypecastable(dstTyp, srcTyp) {
+		return &structcopy.SimpleField{
+			LHS: lhs,
+			RHS: fmt.Sprintf("%s(%s)", g.typeString(dstTyp), rhs),
+		}, nil
+	}
+
+	dstStruct := g.lookupStruct(dstTyp)
+	srcStruct := g.lookupStruct(srcTyp)
+
+	if method.MergeZeroMap[strings.TrimSuffix(dstPath, ".")] {
+		// the field is copied as a whole
+		method.Merge = false
+	}
+	if method.Merge && dstStruct != nil && srcStruct != nil && g.hasAccessibleFields(srcStruct) {
+		return g.mkNestStructAssignment(lhs, dstTyp, dstStruct, rhs, srcTyp, srcStruct, dstPath, method)
+	}
+
+	if types.AssignableTo(srcTyp, dstTyp) {
+		return &structcopy.SimpleField{
+			LHS: lhs,
+			RHS: rhs,
+		}, nil
+	}
+	if dstStruct == nil || srcStruct == nil {
+		return nil, fmt.Errorf("%s: %s of type %s can't be converted to %s of type %s, add a method for them to the interface or use :conv",
+			method.Name, rhs, g.typeString(srcTyp), lhs, g.typeString(dstTyp))
+	}
+
+	assignment, err := g.mkMethodCallAssignment(lhs, dstTyp, rhs, srcTyp, method)
+	if err != nil || assignment != nil {
+		return assignment, err
+	}
+
+	return g.mkNestStructAssignment(lhs, dstTyp, dstStruct, rhs, srcTyp, srcStruct, dstPath, method)
+}
+
+// mkMethodCallAssignment converts rhs into lhs by calling another method of
+// the interface whose signature matches the struct types, adapting pointers
+// where needed. It returns nil when no method matches.
+func (g *Generator) mkMethodCallAssignment(
+	lhs string,
+	dstTyp types.Type,
+	rhs string,
+	srcTyp types.Type,
+	method structcopy.Method,
+) (structcopy.Assignment, error) {
+	call, retErr, err := g.methodCallExpr(dstTyp, rhs, srcTyp, method)
+	if err != nil || call == "" {
+		return nil, err
+	}
+
+	assignment := &structcopy.SimpleField{
+		LHS:   lhs,
+		RHS:   call,
+		Error: retErr,
+	}
+	if _, srcPtr := derefType(srcTyp); !srcPtr {
+		return assignment, nil
+	}
+
+	// generated methods dereference their source, so nil values are left unconverted
+	return &structcopy.NestStruct{
+		NullCheckExpr: rhs,
+		Contents:      []structcopy.Assignment{assignment},
+	}, nil
+}
+
+// methodCallExpr returns the expression converting arg of type srcTyp into
+// dstTyp by calling another method of the interface whose signature matches
+// the struct types, adapting pointers where needed, and forwards the
+// additional arguments of method to it. Pointer arguments must be checked for
+// nil by the caller. retErr reports whether the method also returns an error.
+// It returns an empty call when no method matches, and an error when the
+// matching methods take additional arguments method can't forward.
+func (g *Generator) methodCallExpr(
+	dstTyp types.Type,
+	arg string,
+	srcTyp types.Type,
+	method structcopy.Method,
+) (call string, retErr bool, err error) {
+	dstBase, dstPtr := derefType(dstTyp)
+	srcBase, srcPtr := derefType(srcTyp)
+
+	var candidate *structcopy.Method
+	var candidateArgs []string
+	var paramPtr, resultPtr bool
+	var argsErr error
+	for i := range g.methods {
+		m := &g.methods[i]
+		if len(m.Params) == 0 || m.DstVarStyle == structcopy.DstVarArg || m.FirstParam.IsSlice || m.FirstResult.IsSlice {
+			continue
+		}
+		if len(m.Results) != 1 && (len(m.Results) != 2 || !m.RetError) {
+			continue
+		}
+
+		paramBase, pPtr := derefType(m.FirstParam.Typ)
+		resultBase, rPtr := derefType(m.FirstResult.Typ)
+		if !types.Identical(paramBase, srcBase) || !types.Identical(resultBase, dstBase) {
+			continue
+		}
+		// a value result can't be assigned to a pointer destination in a single expression,
+		// nor can a pointer result be dereferenced along with an error
+		if (!rPtr && dstPtr) || (rPtr && !dstPtr && m.RetError) {
+			continue
+		}
+		args, err := g.additionalArgs(m.Name, lo.Map(m.Params, func(p structcopy.MethodParam, _ int) types.Type { return p.Typ }), 1, method)
+		if err != nil {
+			argsErr = err
+			continue
+		}
+
+		// prefer the method which needs the fewest pointer adaptations
+		if candidate == nil || (pPtr == srcPtr && rPtr == dstPtr) {
+			candidate, candidateArgs, paramPtr, resultPtr = m, args, pPtr, rPtr
+		}
+	}
+	if candidate == nil {
+		if argsErr != nil {
+			return "", false, fmt.Errorf("%s: %w", method.Name, argsErr)
+		}
+		return "", false, nil
+	}
+	g.calls[candidate.Name] = true
+
+	if srcPtr && !paramPtr {
+		arg = "*" + arg
+	} else if !srcPtr && paramPtr {
+		arg = "&" + arg
+	}
+
+	call = callExpr(candidate.Name, arg, candidateArgs)
+	if method.ReceiverType == "s" {
+		call = "c." + call
+	}
+	if resultPtr && !dstPtr {
+		call = "*" + call
+	}
+
+	return call, candidate.RetError, nil
+}
+
+// mkConvertAssignment builds the assignment of rhs to lhs through the :conv
+// function convertFunc. The function is applied to each value of a map, or
+// each element of a slice, when its parameter accepts them rather than the
+// map or the slice itself.
+func (g *Generator) mkConvertAssignment(
+	lhs string,
+	dstTyp types.Type,
+	rhs string,
+	srcTyp types.Type,
+	convertFunc string,
+	method structcopy.Method,
+) (structcopy.Assignment, error) {
+	assignment := &structcopy.ConvertField{
+		LHS:     lhs,
+		RHS:     rhs,
+		Convert: convertFunc,
+	}
+
+	sig := g.lookupFunc(convertFunc)
+	if sig == nil {
+		return nil, fmt.Errorf("conv of %s: %s can't be resolved, check its name and that its package is imported", lhs, convertFunc)
+	}
+	assignment.Error = retError(sig)
+	args, err := g.additionalArgs(convertFunc, tupleTypes(sig.Params()), 1, method)
+	if err != nil {
+		return nil, err
+	}
+	assignment.Args = args
+	if sig.Params().Len() == 0 {
+		_, field, _ := strings.Cut(lhs, ".")
+		return nil, fmt.Errorf("conv of %s: %s takes no argument, use :literal %s %s()",
+			lhs, convertFunc, field, convertFunc)
+	}
+	if sig.Results().Len() == 0 {
+		return nil, fmt.Errorf("conv of %s: %s returns no value", lhs, convertFunc)
+	}
+	if dstTyp == nil || srcTyp == nil {
+		return assignment, nil
+	}
+	paramTyp := sig.Params().At(0).Type()
+	resultTyp := sig.Results().At(0).Type()
+	// checkResult reports a result of convertFunc which can't be assigned to dst
+	checkResult := func(dst types.Type) error {
+		if !types.AssignableTo(resultTyp, dst) {
+			return fmt.Errorf("conv of %s: %s returns %s, which can't be assigned to %s",
+				lhs, convertFunc, g.typeString(resultTyp), g.typeString(dst))
+		}
+		return nil
+	}
+	if types.AssignableTo(srcTyp, paramTyp) {
+		if err := checkResult(dstTyp); err != nil {
+			return nil, err
+		}
+		return assignment, nil
+	}
+
+	dstMap, dstIsMap := dstTyp.Underlying().(*types.Map)
+	srcMap, srcIsMap := srcTyp.Underlying().(*types.Map)
+	if dstIsMap && srcIsMap && types.AssignableTo(srcMap.Elem(), paramTyp) {
+		if !types.AssignableTo(srcMap.Key(), dstMap.Key()) {
+			return nil, fmt.Errorf("map key of %s can't be assigned to %s", rhs, lhs)
+		}
+		if err := checkResult(dstMap.Elem()); err != nil {
+			return nil, err
+		}
+		return &structcopy.MapConvertLoopAssignment{
+			LHS:       lhs,
+			RHS:       rhs,
+			Typ:       g.typeString(dstTyp),
+			ValueExpr: callExpr(convertFunc, "v", args),
+			Error:     assignment.Error,
+		}, nil
+	}
+
+	dstSlice, dstIsSlice := dstTyp.Underlying().(*types.Slice)
+	srcSlice, srcIsSlice := srcTyp.Underlying().(*types.Slice)
+	if dstIsSlice && srcIsSlice && types.AssignableTo(srcSlice.Elem(), paramTyp) {
+		if err := checkResult(dstSlice.Elem()); err != nil {
+			return nil, err
+		}
+		return &structcopy.SliceConvertLoopAssignment{
+			LHS:      lhs,
+			RHS:      rhs,
+			Typ:      g.typeString(dstTyp),
+			ElemExpr: callExpr(convertFunc, "e", args),
+			Error:    assignment.Error,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("conv of %s: %s takes %s, which %s of type %s can't be passed to",
+		lhs, convertFunc, g.typeString(paramTyp), rhs, g.typeString(srcTyp))
+}
+
+// mkMapAssignment copies the map rhs into a new map assigned to lhs,
+// converting its values by another method of the interface when their types
+// differ.
+func (g *Generator) mkMapAssignment(
+	lhs string,
+	dstTyp types.Type,
+	dstMap *types.Map,
+	rhs string,
+	srcMap *types.Map,
+	method structcopy.Method,
+) (structcopy.Assignment, error) {
+	if !types.AssignableTo(srcMap.Key(), dstMap.Key()) {
+		return nil, fmt.Errorf("%s: map key of %s can't be assigned to %s", method.Name, rhs, lhs)
+	}
+
+	if types.AssignableTo(srcMap.Elem(), dstMap.Elem()) {
+		return &structcopy.MapAssignment{
+			LHS: lhs,
+			RHS: rhs,
+			Typ: g.typeString(dstTyp),
+		}, nil
+	}
+
+	valueExpr, nilCheck, retErr, err := g.elemConvertExpr(dstMap.Elem(), "v", srcMap.Elem(), method)
+	if err != nil {
+		return nil, err
+	}
+	if valueExpr == "" {
+		return nil, fmt.Errorf("%s: map values of %s can't be converted to %s, add a method for them to the interface or use :conv",
+			method.Name, rhs, lhs)
+	}
+
+	nilValue := ""
+	if nilCheck {
+		// nil values are stored as the zero value of a destination which can't be nil
+		nilValue = "nil"
+		if _, ok := dstMap.Elem().Underlying().(*types.Struct); ok {
+			nilValue = g.typeString(dstMap.Elem()) + "{}"
+		}
+	}
+
+	return &structcopy.MapConvertLoopAssignment{
+		LHS:       lhs,
+		RHS:       rhs,
+		Typ:       g.typeString(dstTyp),
+		ValueExpr: valueExpr,
+		NilValue:  nilValue,
+		Error:     retErr,
+	}, nil
+}
+
+// mkSliceAssignment copies the slice rhs into a new slice assigned to lhs,
+// converting its elements when their types differ.
+func (g *Generator) mkSliceAssignment(
+	lhs string,
+	dstTyp types.Type,
+	dstSlice *types.Slice,
+	rhs string,
+	srcSlice *types.Slice,
+	method structcopy.Method,
+) (structcopy.Assignment, error) {
+	typ := g.typeString(dstTyp)
+
+	if types.Identical(srcSlice.Elem(), dstSlice.Elem()) {
+		return &structcopy.SliceAssignment{
+			LHS: lhs,
+			RHS: rhs,
+			Typ: typ,
+		}, nil
+	} else if types.AssignableTo(srcSlice.Elem(), dstSlice.Elem()) {
+		return &structcopy.SliceLoopAssignment{
+			LHS: lhs,
+			RHS: rhs,
+			Typ: typ,
+		}, nil
+	} else if isTypecastable(dstSlice.Elem(), srcSlice.Elem()) && !g.isEnumPair(dstSlice.Elem(), srcSlice.Elem()) {
+		return &structcopy.SliceTypecastAssignment{
+			LHS:  lhs,
+			RHS:  rhs,
+			Typ:  typ,
+			Cast: g.typeString(dstSlice.Elem()),
+		}, nil
+	}
+
+	elemExpr, nilCheck, retErr, err := g.elemConvertExpr(dstSlice.Elem(), "e", srcSlice.Elem(), method)
+	if err != nil {
+		return nil, err
+	}
+	if elemExpr == "" {
+		return nil, fmt.Errorf("%s: elements of %s can't be converted to %s, add a method for them to the interface or use :conv",
+			method.Name, rhs, lhs)
+	}
+
+	return &structcopy.SliceConvertLoopAssignment{
+		LHS:      lhs,
+		RHS:      rhs,
+		Typ:      typ,
+		ElemExpr: elemExpr,
+		NilCheck: nilCheck,
+		Error:    retErr,
+	}, nil
+}
+
+// elemConvertExpr returns the expression converting arg, an element of a
+// slice or a map, of type srcTyp into dstTyp: arg itself when assignable, a
+// typecast, or a call to another method of the interface, which enums need
+// to be mapped by. nilCheck reports whether nil elements must be skipped
+// before the conversion, and retErr whether the expression also returns an
+// error. It returns an empty expression when arg can't be converted.
+func (g *Generator) elemConvertExpr(
+	dstTyp types.Type,
+	arg string,
+	srcTyp types.Type,
+	method structcopy.Method,
+) (expr string, nilCheck bool, retErr bool, err error) {
+	if types.AssignableTo(srcTyp, dstTyp) {
+		return arg, false, false, nil
+	}
+	if isTypecastable(dstTyp, srcTyp) && !g.isEnumPair(dstTyp, srcTyp) {
+		return fmt.Sprintf("%s(%s)", g.typeString(dstTyp), arg), false, false, nil
+	}
+
+	if !g.isEnumPair(dstTyp, srcTyp) && (g.lookupStruct(dstTyp) == nil || g.lookupStruct(srcTyp) == nil) {
+		return "", false, false, nil
+	}
+	expr, retErr, err = g.methodCallExpr(dstTyp, arg, srcTyp, method)
+	if err != nil || expr == "" {
+		return "", false, false, err
+	}
+	_, srcPtr := derefType(srcTyp)
+
+	return expr, srcPtr, retErr, nil
+}
+
+// isTypecastable reports whether srcTyp can be safely typecast into dstTyp,
+// i.e. both are basic types sharing the same underlying type, such as
+// entity.Status and string.
+func isTypecastable(dstTyp, srcTyp types.Type) bool {
+	dstBasic, ok := dstTyp.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	srcBasic, ok := srcTyp.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	return types.Identical(dstBasic, srcBasic)
+}
+
+// mkNestStructAssignment converts rhs into lhs field by field. Pointer sources
+// are checked for nil and pointer destinations are allocated first.
+func (g *Generator) mkNestStructAssignment(
+	lhs string,
+	dstTyp types.Type,
+	dstStruct *structcopy.Struct,
+	rhs string,
+	srcTyp types.Type,
+	srcStruct *structcopy.Struct,
+	dstPath string,
+	method structcopy.Method,
+) (structcopy.Assignment, error) {
+	dstBase, dstPtr := derefType(dstTyp)
+	srcBase, srcPtr := derefType(srcTyp)
+
+	key := types.TypeString(srcBase, nil) + "->" + types.TypeString(dstBase, nil)
+	if g.visiting[key] {
+		return nil, fmt.Errorf("%s: recursive conversion from %s to %s needs a method in the interface",
+			method.Name, g.typeString(srcBase), g.typeString(dstBase))
+	}
+	g.visiting[key] = true
+	defer delete(g.visiting, key)
+
+	contents, err := g.mkStructFieldsAssignments(rhs, srcStruct, lhs, dstStruct, dstPath, "", method)
+	if err != nil {
+		return nil, err
+	}
+
+	nestStruct := &structcopy.NestStruct{
+		Contents: contents,
+	}
+	if srcPtr {
+		nestStruct.NullCheckExpr = rhs
+	}
+	if dstPtr {
+		nestStruct.InitExpr = g.allocExpr(lhs, dstBase, method)
+	}
+
+	return nestStruct, nil
+}
+
+// allocExpr returns the statement allocating a new t to the pointer lhs. In
+// merge mode, lhs is allocated only when nil, to merge into its fields.
+func (g *Generator) allocExpr(lhs string, t types.Type, method structcopy.Method) string {
+	alloc := fmt.Sprintf("%s = &%s{}", lhs, g.typeString(t))
+	if method.Merge {
+		return fmt.Sprintf("if %s == nil {\n%s\n}", lhs, alloc)
+	}
+	return alloc
 }
 
 func (g *Generator) mkSliceOfStructToSliceOfStructAssignments(
@@ -145,7 +1328,110 @@ func (g *Generator) mkSliceOfStructToSliceOfStructAssignments(
 		StructConvert: structConverterFunc,
 		ReceiverType:  method.ReceiverType,
 	}
+	if m, ok := lo.Find(g.methods, func(m structcopy.Method) bool { return m.Name == structConverterFunc }); ok {
+		g.calls[m.Name] = true
+		assignment.Error = m.RetError
+		if len(m.AdditionalArgs) > 0 {
+			args, err := g.additionalArgs(structConverterFunc, lo.Map(m.Params, func(p structcopy.MethodParam, _ int) types.Type { return p.Typ }), 1, method)
+			if err != nil {
+				return nil, err
+			}
+			assignment.Args = args
+		}
+	} else if sig := g.lookupFunc(structConverterFunc); sig != nil {
+		assignment.Error = retError(sig)
+		args, err := g.additionalArgs(structConverterFunc, tupleTypes(sig.Params()), 1, method)
+		if err != nil {
+			return nil, err
+		}
+		assignment.Args = args
+	}
 	assignments = append(assignments, assignment)
 
 	return assignments, nil
 }
+
+// mkManipulator resolves the function used by the :preprocess and :postprocess
+// notations and checks it against the method's signature. The function must
+// take the destination and the source, either as values or pointers, followed
+// by the additional arguments of the method if any, and return nothing or an
+// error.
+func (g *Generator) mkManipulator(funcName string, method structcopy.Method) (*structcopy.Manipulator, error) {
+	sig := g.lookupFunc(funcName)
+	if sig == nil {
+		return nil, fmt.Errorf("function %s is not found", funcName)
+	}
+
+	manipulator := &structcopy.Manipulator{Name: funcName}
+	if pkgName, name, ok := strings.Cut(funcName, "."); ok {
+		manipulator.Pkg = pkgName
+		manipulator.Name = name
+	}
+
+	params := sig.Params()
+	if params.Len() < 2 {
+		return nil, fmt.Errorf("%s must take the destination and the source as arguments", funcName)
+	}
+
+	dstParam, isDstPtr := derefType(params.At(0).Type())
+	dstTyp, _ := derefType(method.FirstResult.Typ)
+	if !types.Identical(dstParam, dstTyp) {
+		return nil, fmt.Errorf("the 1st argument of %s must be %s or a pointer to it, got %s",
+			funcName, g.typeString(dstTyp), g.typeString(params.At(0).Type()))
+	}
+	srcParam, isSrcPtr := derefType(params.At(1).Type())
+	srcTyp, _ := derefType(method.FirstParam.Typ)
+	if !types.Identical(srcParam, srcTyp) {
+		return nil, fmt.Errorf("the 2nd argument of %s must be %s or a pointer to it, got %s",
+			funcName, g.typeString(srcTyp), g.typeString(params.At(1).Type()))
+	}
+	manipulator.IsDstPtr = isDstPtr
+	manipulator.IsSrcPtr = isSrcPtr
+
+	args, err := g.additionalArgs(funcName, tupleTypes(params), 2, method)
+	if err != nil {
+		return nil, err
+	}
+	manipulator.HasAdditionalArgs = len(args) > 0
+
+	switch results := sig.Results(); {
+	case results.Len() == 0:
+	case results.Len() == 1 && isErrorType(results.At(0).Type()):
+		manipulator.RetError = true
+	default:
+		return nil, fmt.Errorf("%s must return nothing or an error", funcName)
+	}
+
+	return manipulator, nil
+}
+
+// additionalArgs returns the names of the additional arguments of method to
+// pass to funcName, whose parameters from offset on must match them in order
+// and type. It returns nil when funcName takes no additional arguments.
+func (g *Generator) additionalArgs(funcName string, paramTypes []types.Type, offset int, method structcopy.Method) ([]string, error) {
+	if len(paramTypes) <= offset {
+		return nil, nil
+	}
+
+	paramTypes = paramTypes[offset:]
+	if len(paramTypes) != len(method.AdditionalArgs) {
+		return nil, fmt.Errorf("%s takes %d additional arguments, but %s has %d",
+			funcName, len(paramTypes), method.Name, len(method.AdditionalArgs))
+	}
+
+	args := make([]string, 0, len(paramTypes))
+	for i, arg := range method.AdditionalArgs {
+		if paramType := g.typeString(paramTypes[i]); paramType != arg.FullType() {
+			return nil, fmt.Errorf("the argument %s of %s must be %s, got %s",
+				arg.Name, funcName, arg.FullType(), paramType)
+		}
+		args = append(args, arg.Name)
+	}
+	return args, nil
+}
+
+// callExpr returns the expression calling funcName with arg followed by the
+// additional arguments.
+func callExpr(funcName, arg string, args []string) string {
+	return fmt.Sprintf("%s(%s)", funcName, strings.Join(append([]string{arg}, args...), ", "))
+}
@@ -0,0 +1,15 @@
+package gen
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	assertGolden(t, filepath.Join("testdata", "merge", "structcopy-gen.go"))
+}
+
+func TestMergeStructWithoutIsZero(t *testing.T) {
+	assertError(t, filepath.Join("testdata", "errors", "merge_struct.go"),
+		"merge of Origin: Point can't be compared to its zero value, use :merge_zero")
+}
@@ -0,0 +1,158 @@
+package gen
+
+import (
+	"cmp"
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"github.com/structcopy/structcopy-gen/pkg/structcopy"
+)
+
+// enumConsts returns the constants declared with the named type t in its
+// package, in the order of their declaration. The unexported constants of
+// another package are left out, as well as the constants repeating the value
+// of a previous one, which can't be cases of the same switch.
+func (g *Generator) enumConsts(t types.Type) []*types.Const {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+	if _, ok := named.Underlying().(*types.Basic); !ok {
+		return nil
+	}
+
+	pkg := named.Obj().Pkg()
+	var consts []*types.Const
+	for _, name := range pkg.Scope().Names() {
+		c, ok := pkg.Scope().Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), named) {
+			continue
+		}
+		if pkg != g.pkg.Types && !c.Exported() {
+			continue
+		}
+		consts = append(consts, c)
+	}
+	slices.SortFunc(consts, func(a, b *types.Const) int { return cmp.Compare(a.Pos(), b.Pos()) })
+
+	var result []*types.Const
+	for _, c := range consts {
+		if !slices.ContainsFunc(result, func(r *types.Const) bool { return constant.Compare(r.Val(), token.EQL, c.Val()) }) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// isEnum reports whether t is a named type with constants, e.g. "Status" with
+// "StatusActive" and "StatusInactive".
+func (g *Generator) isEnum(t types.Type) bool {
+	return len(g.enumConsts(t)) > 0
+}
+
+// isEnumPair reports whether dstTyp and srcTyp are distinct enum types, which
+// are mapped by a switch on their constants rather than typecast.
+func (g *Generator) isEnumPair(dstTyp, srcTyp types.Type) bool {
+	return !types.Identical(dstTyp, srcTyp) && g.isEnum(dstTyp) && g.isEnum(srcTyp)
+}
+
+// constString returns the expression of the constant c as written in the
+// generated file, e.g. "entity.StatusActive".
+func (g *Generator) constString(c *types.Const) string {
+	if q := g.qualifier(c.Pkg()); q != "" {
+		return q + "." + c.Name()
+	}
+	return c.Name()
+}
+
+// enumKey returns the key pairing the constant name of the enum type typeName
+// with the constants of another enum type, i.e. its name without the name of
+// its type, e.g. "Active" for "StatusActive", normalized as a field name.
+func enumKey(name string, typeName string, method structcopy.Method) string {
+	if s, ok := strings.CutPrefix(name, typeName); ok && s != "" {
+		name = s
+	}
+	return normalizeName(name, method)
+}
+
+// mkEnumAssignment builds the switch assigning to lhs the constant of dstTyp
+// paired with the constant of srcTyp held by rhs. The constants are paired by
+// :enum_pair, or by their name without the name of their type. It fails when
+// a source constant has no pair, unless :enum_default gives the constant the
+// other values are mapped to.
+func (g *Generator) mkEnumAssignment(
+	lhs string,
+	dstTyp types.Type,
+	rhs string,
+	srcTyp types.Type,
+	method structcopy.Method,
+) (structcopy.Assignment, error) {
+	dstConsts := g.enumConsts(dstTyp)
+	srcConsts := g.enumConsts(srcTyp)
+	if len(dstConsts) == 0 || len(srcConsts) == 0 {
+		return nil, fmt.Errorf("%s: enum map of %s to %s: both types need constants",
+			method.Name, g.typeString(srcTyp), g.typeString(dstTyp))
+	}
+	dstTypeName := types.Unalias(dstTyp).(*types.Named).Obj().Name()
+	srcTypeName := types.Unalias(srcTyp).(*types.Named).Obj().Name()
+
+	dstByName := map[string]*types.Const{}
+	dstByKey := map[string]*types.Const{}
+	for _, c := range dstConsts {
+		dstByName[c.Name()] = c
+		if key := enumKey(c.Name(), dstTypeName, method); dstByKey[key] == nil {
+			dstByKey[key] = c
+		}
+	}
+
+	assignment := &structcopy.EnumSwitchAssignment{
+		LHS: lhs,
+		RHS: rhs,
+	}
+	// the default and the pairs may be given for another enum type of the
+	// method, but must apply to one of them
+	if c, ok := dstByName[method.EnumDefault]; ok {
+		assignment.Default = g.constString(c)
+		g.usedEnumNotations[":enum_default "+method.EnumDefault] = true
+	}
+
+	caseIndex := map[*types.Const]int{}
+	var unmapped []string
+	for _, c := range srcConsts {
+		dst := dstByKey[enumKey(c.Name(), srcTypeName, method)]
+		// the pairs of the method come after the inherited ones, which they override
+		for _, pair := range method.EnumPairs {
+			if p, ok := dstByName[pair.Dst]; ok && pair.Src == c.Name() {
+				dst = p
+				g.usedEnumNotations[pair.String()] = true
+			}
+		}
+		if dst == nil {
+			if assignment.Default == "" {
+				unmapped = append(unmapped, g.constString(c))
+			}
+			continue
+		}
+
+		// the source constants paired with the same destination share a case
+		if i, ok := caseIndex[dst]; ok {
+			assignment.Cases[i].Src = append(assignment.Cases[i].Src, g.constString(c))
+			continue
+		}
+		caseIndex[dst] = len(assignment.Cases)
+		assignment.Cases = append(assignment.Cases, structcopy.EnumCase{
+			Src: []string{g.constString(c)},
+			Dst: g.constString(dst),
+		})
+	}
+	if len(unmapped) > 0 {
+		return nil, fmt.Errorf("%s: no %s for %s, add :enum_pair or :enum_default",
+			method.Name, g.typeString(dstTyp), strings.Join(unmapped, ", "))
+	}
+
+	return assignment, nil
+}
@@ -0,0 +1,27 @@
+package gen
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestEnums(t *testing.T) {
+	assertGolden(t, filepath.Join("testdata", "enums", "structcopy-gen.go"))
+}
+
+func TestEnumErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "enum_unpaired", want: "StatusToCode: no StatusCode for StatusSuspended, add :enum_pair or :enum_default"},
+		{name: "enum_default", want: "StatusToCode: :enum_default StatusCodeUnknown matches no constants of the enums mapped by the method"},
+		{name: "enum_pair", want: "StatusToCode: :enum_pair StatusCodeDisabled StatusBanned matches no constants of the enums mapped by the method"},
+		{name: "enum_interface", want: "EnumInterfaceConverter: :enum_default StatusCodeUnknown matches no constants of the enums mapped by its methods"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertError(t, filepath.Join("testdata", "errors", tt.name+".go"), tt.want)
+		})
+	}
+}
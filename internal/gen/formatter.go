@@ -76,14 +76,33 @@ func (g *Generator) generateContent() (content []byte, err error) {
 			}
 		}
 
+		// function variables calling themselves are assigned in init()
+		var initMethods bytes.Buffer
 		for _, method := range inf.Methods {
+			if method.HandWritten {
+				continue
+			}
+			out := &sb
+			if method.InitAssigned {
+				out = &initMethods
+			}
 			if method.FirstParam.IsSlice && method.FirstResult.IsSlice &&
 				method.FirstParam.IsStruct && method.FirstResult.IsStruct {
-				sb.WriteString(method.FormatSliceOfStruct())
-			} else if method.FirstParam.IsStruct && method.FirstResult.IsStruct &&
-				method.FirstParam.StructDef != nil {
-				sb.WriteString(method.String())
+				out.WriteString(method.FormatSliceOfStruct())
+			} else if (method.FirstParam.IsStruct && method.FirstResult.IsStruct &&
+				method.FirstParam.StructDef != nil) || method.EnumMap {
+				out.WriteString(method.String())
+			} else {
+				continue
 			}
+			if method.InitAssigned {
+				sb.WriteString(method.FuncVarDecl() + "\n")
+			}
+		}
+		if initMethods.Len() > 0 {
+			sb.WriteString("func init() {\n")
+			sb.WriteString(strings.TrimSuffix(initMethods.String(), "\n"))
+			sb.WriteString("}\n\n")
 		}
 	}
 
@@ -105,16 +124,27 @@ func (g *Generator) WriteReceiver(inf structcopy.Interface) string {
 	sb.WriteString("type ")
 	sb.WriteString(inf.ReceiverName)
 	sb.WriteString(" struct {\n")
+	for _, field := range inf.ReceiverFields {
+		sb.WriteString(field.Name + " " + field.Typ + "\n")
+	}
 	sb.WriteString("}\n\n")
 
+	// the fields of the receiver struct are given to its constructor
+	params := make([]string, 0, len(inf.ReceiverFields))
+	values := make([]string, 0, len(inf.ReceiverFields))
+	for _, field := range inf.ReceiverFields {
+		params = append(params, field.Name+" "+field.Typ)
+		values = append(values, field.Name+": "+field.Name)
+	}
+
 	sb.WriteString("func New")
 	sb.WriteString(inf.Name)
-	sb.WriteString("() ")
+	sb.WriteString("(" + strings.Join(params, ", ") + ") ")
 	sb.WriteString(inf.Name)
 	sb.WriteString(" {\n")
 	sb.WriteString("	return &")
 	sb.WriteString(inf.ReceiverName)
-	sb.WriteString("{}\n")
+	sb.WriteString("{" + strings.Join(values, ", ") + "}\n")
 	sb.WriteString("}\n\n")
 
 	return sb.String()
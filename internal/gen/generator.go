@@ -1,10 +1,13 @@
 package gen
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 	"log/slog"
+	"maps"
 	"os"
 	"slices"
 	"strings"
@@ -25,6 +28,31 @@ type Generator struct {
 	output string
 	log    string
 	logs   bool
+	// strict fails the generation on unmatched destination fields of all methods.
+	strict bool
+	// reportUnusedSrc reports the unused source fields of all methods.
+	reportUnusedSrc structcopy.UnusedSrcReport
+
+	// structs caches resolved struct definitions by fully qualified type name.
+	structs map[string]*structcopy.Struct
+	// importNames maps import paths to the names used in the generated code.
+	importNames     map[string]string
+	usedImportNames map[string]bool
+
+	// methods holds the methods of the interface being generated.
+	methods []structcopy.Method
+	// visiting holds the struct pairs being converted inline, to detect recursive types.
+	visiting map[string]bool
+	// usedSrcFields holds the source fields read by the method being built, e.g. "src.Name".
+	usedSrcFields map[string]bool
+	// calls holds the methods of the interface called by the method being built.
+	calls map[string]bool
+	// usedEnumNotations holds the :enum_pair and :enum_default notations applied
+	// by the method being built, e.g. ":enum_default PlanUnknown".
+	usedEnumNotations map[string]bool
+	// receiverFields holds the types of the fields of the receiver struct of the
+	// interface being built, by name.
+	receiverFields map[string]types.Type
 
 	logger *slog.Logger
 }
@@ -32,10 +60,17 @@ type Generator struct {
 // NewGenerator returns new Generator instance.
 func NewGenerator(pkg *packages.Package, fset *token.FileSet, file *ast.File, opts ...GeneratorOption) (*Generator, error) {
 	g := &Generator{
-		pkg:  pkg,
-		fset: fset,
-		file: file,
-		spec: &structcopy.Spec{},
+		pkg:      pkg,
+		fset:     fset,
+		file:     file,
+		spec:     &structcopy.Spec{},
+		structs:  map[string]*structcopy.Struct{},
+		visiting: map[string]bool{},
+
+		usedSrcFields:     map[string]bool{},
+		calls:             map[string]bool{},
+		usedEnumNotations: map[string]bool{},
+		receiverFields:    map[string]types.Type{},
 	}
 	g.initDefaults()
 
@@ -44,7 +79,6 @@ func NewGenerator(pkg *packages.Package, fset *token.FileSet, file *ast.File, op
 	}
 
 	pkgName := file.Name.Name
-	pkgPath := pkg.PkgPath
 	g.spec.PackageName = pkgName
 
 	for _, imp := range file.Imports {
@@ -62,19 +96,7 @@ func NewGenerator(pkg *packages.Package, fset *token.FileSet, file *ast.File, op
 
 		g.spec.Imports = append(g.spec.Imports, currentImport)
 	}
-
-	structs := map[string]*ast.StructType{}
-	parsedStructs := map[string]*structcopy.Struct{}
-
-	// ✅ Collect structs from local + imported packages
-	for _, file := range pkg.Syntax {
-		collectStructs(file, structs, parsedStructs, pkgPath, pkgName)
-	}
-	for _, imp := range pkg.Imports {
-		for _, file := range imp.Syntax {
-			collectStructs(file, structs, parsedStructs, pkgPath, pkgName)
-		}
-	}
+	g.initImports()
 
 	// Traverse the AST
 	for _, decl := range file.Decls {
@@ -104,17 +126,16 @@ func NewGenerator(pkg *packages.Package, fset *token.FileSet, file *ast.File, op
 				currentInfOptions := &structcopy.InterfaceOption{}
 				var err error
 
-				if genDecl.Doc.List != nil {
-					// when interface is not in a type group, comments is stayed at genDecl
-					currentInfOptions, err = g.CollectInterfaceOptions(genDecl.Doc.List, ValidOpsIntf)
-					if err != nil {
-						g.logger.Error("collect interface options failed", slog.Any("error", err))
+				// when interface is not in a type group, comments is stayed at genDecl,
+				// otherwise at typeSpec
+				if interfaceDoc := genDecl.Doc; interfaceDoc != nil || typeSpec.Doc != nil {
+					if interfaceDoc == nil {
+						interfaceDoc = typeSpec.Doc
 					}
-				} else if typeSpec.Doc.List != nil {
-					// when interface is in a type group, comments is stayed at typeSpec
-					currentInfOptions, err = g.CollectInterfaceOptions(typeSpec.Doc.List, ValidOpsIntf)
+					currentInfOptions, err = g.CollectInterfaceOptions(interfaceDoc.List, ValidOpsIntf)
 					if err != nil {
 						g.logger.Error("collect interface options failed", slog.Any("error", err))
+						return nil, fmt.Errorf("%v: %s: %w", g.fset.Position(typeSpec.Pos()), typeSpec.Name.Name, err)
 					}
 				}
 
@@ -135,14 +156,51 @@ func NewGenerator(pkg *packages.Package, fset *token.FileSet, file *ast.File, op
 
 				currentInterface.ReceiverType = currentInfOptions.ReceiverType
 				currentInterface.ReceiverName = currentInfOptions.ReceiverName
+				currentInterface.ReceiverFields = currentInfOptions.ReceiverFields
+
+				// the fields of the receiver struct can be referred to by the converters, e.g. "c.clock.Now"
+				g.receiverFields = map[string]types.Type{}
+				for _, field := range currentInfOptions.ReceiverFields {
+					if currentInterface.ReceiverType != "s" {
+						return nil, fmt.Errorf("%v: %s: receiver_field needs receiver_type s",
+							g.fset.Position(typeSpec.Pos()), interfaceName)
+					}
+					if _, ok := g.receiverFields[field.Name]; ok {
+						return nil, fmt.Errorf("%v: %s: receiver_field %s is declared twice",
+							g.fset.Position(typeSpec.Pos()), interfaceName, field.Name)
+					}
+					typ, err := g.evalType(field.Typ)
+					if err != nil {
+						return nil, fmt.Errorf("%v: %s: receiver_field %s: %w",
+							g.fset.Position(typeSpec.Pos()), interfaceName, field.Name, err)
+					}
+					g.receiverFields[field.Name] = typ
+				}
 
-				if typeSpec.Doc != nil {
-					// opts := option.NewOptions()
-					// for _, comment := range typeSpec.Doc.List {
-					// }
-					// currentInterfaceOptions, _ = g.CollectOptions(typeSpec.Doc.List, option.ValidOpsMethod)
+				// notations of the interface are inherited by all its methods
+				currentInterfaceOptions := &structcopy.InputOption{}
+				if interfaceDoc := genDecl.Doc; interfaceDoc != nil || typeSpec.Doc != nil {
+					if interfaceDoc == nil {
+						interfaceDoc = typeSpec.Doc
+					}
+					currentInterfaceOptions, err = g.CollectOptions(interfaceDoc.List, ValidOpsIntf)
+					if err != nil {
+						g.logger.Error("collect options failed", slog.Any("error", err))
+						return nil, err
+					}
 				}
 
+				// positions holds the declarations of the methods, to report their errors
+				positions := map[string]token.Position{}
+
+				// methodOptions holds the notations given to the methods, before
+				// inheriting the ones of the interface
+				methodOptions := map[string]*structcopy.InputOption{}
+				signatures := map[string]*types.Signature{}
+
+				// reverses holds the methods to synthesize from the :reverse notations
+				var reverses []reverseMethod
+
 				// Iterate over the method list of the interface
 				if interfaceType.Methods != nil {
 					for _, method := range interfaceType.Methods.List {
@@ -153,12 +211,7 @@ func NewGenerator(pkg *packages.Package, fset *token.FileSet, file *ast.File, op
 						methodName := method.Names[0].Name
 
 						g.logger.Info(fmt.Sprintf("Valid Method: %s", methodName))
-
-						// Initialize a new Method struct
-						currentMethod := structcopy.Method{
-							Name:        method.Names[0].Name,
-							DstVarStyle: structcopy.DstVarReturn,
-						}
+						positions[methodName] = g.fset.Position(method.Pos())
 
 						currentMethodOptions := &structcopy.InputOption{}
 						var err error
@@ -171,62 +224,177 @@ func NewGenerator(pkg *packages.Package, fset *token.FileSet, file *ast.File, op
 							}
 							g.logger.Info("Valid annotations")
 						}
+						methodOptions[methodName] = currentMethodOptions
+						currentMethodOptions = inheritOptions(currentInterfaceOptions, currentMethodOptions)
 
-						currentMethod.ReceiverType = currentInfOptions.ReceiverType
-						currentMethod.ReceiverName = currentInfOptions.ReceiverName
-						currentMethod.SkipFieldsMap = currentMethodOptions.SkipFieldsMap
-						currentMethod.MatchFieldsMap = currentMethodOptions.MatchFieldsMap
-						currentMethod.MatchMethodsMap = currentMethodOptions.MatchMethodsMap
-						currentMethod.ConvertersMap = currentMethodOptions.ConvertersMap
-						currentMethod.StructConverterFunc = currentMethodOptions.StructConverterFunc
-
-						// Get the Function Type (*ast.FuncType) of the method
-						funcType, ok := method.Type.(*ast.FuncType)
+						// Resolve the method signature from the type information
+						funcObj, ok := pkg.TypesInfo.Defs[method.Names[0]].(*types.Func)
 						if !ok {
-							g.logger.Info("ERROR: Method type is not *ast.FuncType")
+							g.logger.Info("ERROR: Method type is not *types.Func")
 							continue
 						}
+						signature := funcObj.Type().(*types.Signature)
+						signatures[methodName] = signature
 
-						// --- Parameters (Inputs) ---
-						// paramStrs := []string{}
-						if funcType.Params != nil {
-							for _, paramField := range funcType.Params.List {
-								var params []structcopy.MethodParam
-								params = append(params, parseMethodParams(pkgName, paramField, parsedStructs)...)
-								currentMethod.Params = params
-							}
+						currentMethod, err := g.mkMethod(interfaceName, methodName, signature, currentMethodOptions, currentInfOptions)
+						if err != nil {
+							return nil, err
+						}
+						currentInterface.Methods = append(currentInterface.Methods, currentMethod)
 
-							if len(currentMethod.Params) > 0 {
-								currentMethod.FirstParam = currentMethod.Params[0]
-							}
+						if currentMethodOptions.ReverseMethod != "" {
+							reverses = append(reverses, reverseMethod{
+								name:      currentMethodOptions.ReverseMethod,
+								forward:   currentMethod,
+								signature: signature,
+								options:   currentMethodOptions,
+								pos:       g.notationPosition(method.Doc, "reverse"),
+							})
 						}
+					}
+				}
 
-						// --- Results (Outputs) ---
-						// resultStrs := []string{}
-						if funcType.Results != nil {
-							for _, resultField := range funcType.Results.List {
-								var results []structcopy.MethodResult
-								results = append(results, parseMethodResults(pkgName, resultField, parsedStructs)...)
-								currentMethod.Results = results
-							}
+				// Synthesize the inverse methods once all names are known, so that
+				// their :struct_conv can refer to the inverse of another method.
+				reverseNames := map[string]string{}
+				for _, r := range reverses {
+					reverseNames[r.forward.Name] = r.name
+				}
+				for _, r := range reverses {
+					signature, err := reverseSignature(r.signature)
+					if err != nil {
+						return nil, fmt.Errorf("%v: %s.%s: reverse: %w", r.pos, interfaceName, r.forward.Name, err)
+					}
+					options, err := g.reverseOptions(r.forward, r.options, reverseNames)
+					if err != nil {
+						return nil, fmt.Errorf("%v: %s.%s: reverse: %w", r.pos, interfaceName, r.forward.Name, err)
+					}
 
-							if len(currentMethod.Results) > 0 {
-								currentMethod.FirstResult = currentMethod.Results[0]
-							}
+					// an inverse declared in the interface is generated with the inverted
+					// notations along with its own ones; with receiver type s, it must
+					// be declared to be reachable through the interface
+					if declared := slices.IndexFunc(currentInterface.Methods, func(m structcopy.Method) bool { return m.Name == r.name }); declared >= 0 {
+						if !isReverseSignature(signatures[r.name], signature) {
+							return nil, fmt.Errorf("%v: %s.%s: the reverse of %s must be %s",
+								positions[r.name], interfaceName, r.name, r.forward.Name, types.TypeString(signature, g.qualifier))
 						}
+						reverse, err := g.mkMethod(interfaceName, r.name, signatures[r.name], inheritOptions(options, methodOptions[r.name]), currentInfOptions)
+						if err != nil {
+							return nil, err
+						}
+						currentInterface.Methods[declared] = reverse
+						continue
+					}
+					if currentInterface.ReceiverType == "s" {
+						return nil, fmt.Errorf("%v: %s.%s: reverse method %s must be declared in the interface with receiver_type s",
+							r.pos, interfaceName, r.forward.Name, r.name)
+					}
+
+					reverse, err := g.mkMethod(interfaceName, r.name, signature, options, currentInfOptions)
+					if err != nil {
+						return nil, err
+					}
+					positions[r.name] = r.pos
+					currentInterface.Methods = append(currentInterface.Methods, reverse)
+				}
+
+				// Methods hand-written on the receiver struct elsewhere in the package
+				// aren't generated, but the generated ones still call them.
+				if currentInterface.ReceiverType == "s" {
+					declared := declaredMethods(pkg, currentInterface.ReceiverName)
+					for i := range currentInterface.Methods {
+						currentMethod := &currentInterface.Methods[i]
+						if declared[currentMethod.Name] {
+							g.logger.Info(fmt.Sprintf("Skip hand-written method: %s", currentMethod.Name))
+							currentMethod.HandWritten = true
+						}
+					}
+				}
 
+				// Build assignments once all signatures are known, so that nested
+				// structs can be converted by calling other methods of the interface.
+				// A method returns an error when one of its assignments does, which
+				// may in turn make the methods calling it return an error, so the
+				// assignments are rebuilt until no more method starts returning one.
+				g.methods = currentInterface.Methods
+				unusedSrcFields := map[string][]string{}
+				calls := map[string]map[string]bool{}
+				usedEnumNotations := map[string]map[string]bool{}
+				for changed := true; changed; {
+					changed = false
+					for i := range currentInterface.Methods {
+						currentMethod := &currentInterface.Methods[i]
+						if currentMethod.HandWritten {
+							continue
+						}
+						g.usedSrcFields = map[string]bool{}
+						g.calls = map[string]bool{}
+						g.usedEnumNotations = map[string]bool{}
 						assignments, err := g.mkMethodAssignments(
 							currentMethod.FirstParam,
 							currentMethod.FirstResult,
-							currentMethod,
+							*currentMethod,
 						)
 						if err != nil {
 							g.logger.Error("make assignments failed", slog.Any("error", err))
 							return nil, err
 						}
 						currentMethod.Assignments = assignments
+						unusedSrcFields[currentMethod.Name] = g.unusedSrcFields(*currentMethod)
+						calls[currentMethod.Name] = g.calls
+						usedEnumNotations[currentMethod.Name] = g.usedEnumNotations
 
-						currentInterface.Methods = append(currentInterface.Methods, currentMethod)
+						if !currentMethod.RetError && structcopy.ReturnsError(assignments) {
+							currentMethod.RetError = true
+							changed = true
+						}
+					}
+				}
+
+				for i := range currentInterface.Methods {
+					// a function variable calling itself, directly or not, can't be
+					// initialized by its declaration
+					currentMethod := &currentInterface.Methods[i]
+					currentMethod.InitAssigned = currentMethod.ReceiverType == "f" && callsItself(currentMethod.Name, calls)
+				}
+
+				for _, currentMethod := range currentInterface.Methods {
+					// the receiver struct must implement the interface, so its signatures can't gain an error
+					if currentMethod.RetError && !declaresError(currentMethod) && currentMethod.ReceiverType == "s" {
+						return nil, fmt.Errorf("%s.%s: a converter returns an error, add error to the method results",
+							interfaceName, currentMethod.Name)
+					}
+					if unmatched := structcopy.UnmatchedFields(currentMethod.Assignments); currentMethod.Strict && len(unmatched) > 0 {
+						return nil, fmt.Errorf("%v: %s.%s: no match for %s, add :match_field or :skip_field",
+							positions[currentMethod.Name], interfaceName, currentMethod.Name, strings.Join(unmatched, ", "))
+					}
+					if options, ok := methodOptions[currentMethod.Name]; ok && !currentMethod.HandWritten {
+						for _, notation := range enumNotations(options) {
+							if !usedEnumNotations[currentMethod.Name][notation] {
+								return nil, fmt.Errorf("%v: %s.%s: %s matches no constants of the enums mapped by the method",
+									positions[currentMethod.Name], interfaceName, currentMethod.Name, notation)
+							}
+						}
+					}
+					if unused := unusedSrcFields[currentMethod.Name]; currentMethod.ReportUnusedSrc != "" && len(unused) > 0 {
+						msg := fmt.Sprintf("%v: %s.%s: unused source fields %s",
+							positions[currentMethod.Name], interfaceName, currentMethod.Name, strings.Join(unused, ", "))
+						if currentMethod.ReportUnusedSrc == structcopy.UnusedSrcReportError {
+							return nil, errors.New(msg)
+						}
+						g.logger.Warn(msg)
+					}
+				}
+
+				// the enum notations of the interface must apply to one of its methods
+				usedByMethods := map[string]bool{}
+				for _, used := range usedEnumNotations {
+					maps.Copy(usedByMethods, used)
+				}
+				for _, notation := range enumNotations(currentInterfaceOptions) {
+					if !usedByMethods[notation] {
+						return nil, fmt.Errorf("%v: %s: %s matches no constants of the enums mapped by its methods",
+							g.fset.Position(typeSpec.Pos()), interfaceName, notation)
 					}
 				}
 
@@ -238,6 +406,183 @@ func NewGenerator(pkg *packages.Package, fset *token.FileSet, file *ast.File, op
 	return g, nil
 }
 
+// notationPosition returns the position of the notation name in doc, e.g.
+// "reverse" for ":reverse DTOToUser".
+func (g *Generator) notationPosition(doc *ast.CommentGroup, name string) token.Position {
+	for _, c := range doc.List {
+		if m := reNotation.FindStringSubmatch(c.Text); m != nil && m[1] == name {
+			return g.fset.Position(c.Pos())
+		}
+	}
+	return g.fset.Position(doc.Pos())
+}
+
+// enumNotations returns the :enum_default and :enum_pair notations of options.
+func enumNotations(options *structcopy.InputOption) []string {
+	var notations []string
+	if options.EnumDefault != "" {
+		notations = append(notations, ":enum_default "+options.EnumDefault)
+	}
+	for _, pair := range options.EnumPairs {
+		notations = append(notations, pair.String())
+	}
+	return notations
+}
+
+// mkMethod builds the method of the interface from its signature and its
+// notations, leaving its assignments to be built once all methods are known.
+func (g *Generator) mkMethod(
+	interfaceName string,
+	name string,
+	signature *types.Signature,
+	options *structcopy.InputOption,
+	infOptions *structcopy.InterfaceOption,
+) (structcopy.Method, error) {
+	// Initialize a new Method struct
+	method := structcopy.Method{
+		Name:        name,
+		DstVarStyle: structcopy.DstVarReturn,
+	}
+
+	method.ReceiverType = infOptions.ReceiverType
+	method.ReceiverName = infOptions.ReceiverName
+	method.SkipFieldsMap = options.SkipFieldsMap
+	method.MatchFieldsMap = options.MatchFieldsMap
+	method.MatchMethodsMap = options.MatchMethodsMap
+	method.MatchSettersMap = options.MatchSettersMap
+	method.ConvertersMap = options.ConvertersMap
+	method.LiteralsMap = options.LiteralsMap
+	method.DefaultsMap = options.DefaultsMap
+	method.NoPromoteMap = options.NoPromoteMap
+	method.Merge = options.Merge
+	method.MergeZeroMap = options.MergeZeroMap
+	method.EnumMapsMap = options.EnumMapsMap
+	method.EnumPairs = options.EnumPairs
+	method.EnumDefault = options.EnumDefault
+	method.MatchRule = options.MatchRule
+	method.MatchTagKey = options.MatchTagKey
+	method.NameMatch = options.NameMatch
+	method.StripPrefixes = options.StripPrefixes
+	method.StripSuffixes = options.StripSuffixes
+	method.UseGetters = options.UseGetters
+	method.UseSetters = options.UseSetters
+	method.Strict = options.Strict || g.strict
+	method.ReportUnusedSrc = options.ReportUnusedSrc
+	if method.ReportUnusedSrc != structcopy.UnusedSrcReportError && g.reportUnusedSrc != "" {
+		method.ReportUnusedSrc = g.reportUnusedSrc
+	}
+	method.StructConverterFunc = options.StructConverterFunc
+
+	// "CopyInto(dst *DstModel, src *SrcModel) [error]" takes the destination as an argument
+	if isDstVarArg(signature) {
+		method.DstVarStyle = structcopy.DstVarArg
+	}
+
+	// --- Parameters (Inputs) ---
+	for i := 0; i < signature.Params().Len(); i++ {
+		defaultName := "src"
+		if method.DstVarStyle == structcopy.DstVarArg && i == 0 {
+			defaultName = "dst"
+		}
+		method.Params = append(method.Params, g.parseMethodParam(signature.Params().At(i), defaultName))
+	}
+
+	// --- Results (Outputs) ---
+	for i := 0; i < signature.Results().Len(); i++ {
+		method.Results = append(method.Results, g.parseMethodResult(signature.Results().At(i), "dst"))
+	}
+
+	// parameters following the source are additional arguments
+	var additionalParams []*types.Var
+	if method.DstVarStyle == structcopy.DstVarArg {
+		method.FirstResult = structcopy.MethodResult(method.Params[0])
+		method.FirstParam = method.Params[1]
+		additionalParams = slices.Collect(signature.Params().Variables())[2:]
+	} else {
+		if len(method.Params) > 0 {
+			method.FirstParam = method.Params[0]
+			additionalParams = slices.Collect(signature.Params().Variables())[1:]
+		}
+		if len(method.Results) > 0 {
+			method.FirstResult = method.Results[0]
+		}
+	}
+	for i, v := range additionalParams {
+		method.AdditionalArgs = append(method.AdditionalArgs, g.parseVariable(v, fmt.Sprintf("arg%d", i)))
+	}
+	method.RetError = declaresError(method)
+	method.EnumMap = len(method.Params) > 0 && len(method.Results) > 0 &&
+		g.isEnum(method.FirstParam.Typ) && g.isEnum(method.FirstResult.Typ)
+
+	var err error
+	if options.PreProcessFunc != "" {
+		method.PreProcess, err = g.mkManipulator(options.PreProcessFunc, method)
+		if err != nil {
+			return structcopy.Method{}, fmt.Errorf("%s.%s: preprocess: %w", interfaceName, name, err)
+		}
+	}
+	if options.PostProcessFunc != "" {
+		method.PostProcess, err = g.mkManipulator(options.PostProcessFunc, method)
+		if err != nil {
+			return structcopy.Method{}, fmt.Errorf("%s.%s: postprocess: %w", interfaceName, name, err)
+		}
+	}
+	if (method.PreProcess != nil && method.PreProcess.RetError) ||
+		(method.PostProcess != nil && method.PostProcess.RetError) {
+		method.RetError = true
+	}
+
+	return method, nil
+}
+
+// callsItself reports whether the method name calls itself, directly or
+// through the other methods, following calls which maps the methods to the
+// ones they call.
+func callsItself(name string, calls map[string]map[string]bool) bool {
+	visited := map[string]bool{}
+	var visit func(caller string) bool
+	visit = func(caller string) bool {
+		for callee := range calls[caller] {
+			if callee == name {
+				return true
+			}
+			if !visited[callee] {
+				visited[callee] = true
+				if visit(callee) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return visit(name)
+}
+
+// declaredMethods returns the names of the methods declared on the type name
+// in the files of pkg. The previously generated file isn't loaded, so these
+// are the hand-written ones.
+func declaredMethods(pkg *packages.Package, name string) map[string]bool {
+	result := map[string]bool{}
+	for _, file := range pkg.Syntax {
+		if file == nil {
+			continue
+		}
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+				continue
+			}
+			if _, ok := pkg.TypesInfo.Defs[funcDecl.Name].(*types.Func); !ok {
+				continue
+			}
+			if extractTypeName(funcDecl.Recv.List[0].Type) == name {
+				result[funcDecl.Name.Name] = true
+			}
+		}
+	}
+	return result
+}
+
 // Helper function to extract the type string from an ast.Expr
 func extractType(expr ast.Expr) string {
 	// We use the basic ast.Inspect for a simple, recursive traversal
@@ -387,6 +732,20 @@ func (g *Generator) CollectInterfaceOptions(notations []*ast.Comment, validOps m
 			dst := args[0]
 
 			inputOption.ReceiverName = dst
+		case "receiver_field":
+			typ := reLiteral.FindStringSubmatch(m[2])
+			if len(args) < 2 || typ == nil {
+				return nil, fmt.Errorf("%v: needs <name> <type> args", g.fset.Position(n.Pos()))
+			}
+
+			inputOption.ReceiverFields = append(inputOption.ReceiverFields, structcopy.ReceiverField{
+				Name: args[0],
+				Typ:  strings.TrimSpace(typ[1]),
+			})
+		case "skip_field", "match_field", "match_method", "match_setter", "conv", "literal", "default", "no_promote",
+			"match_rule", "name_match", "strip_prefix", "strip_suffix", "use_getters", "use_setters", "strict",
+			"warn_unused_src", "enum_map", "enum_pair", "enum_default":
+			// inherited by the methods, collected by CollectOptions
 		default:
 			fmt.Printf("%v: unknown notation %v\n", g.fset.Position(n.Pos()), m[1])
 		}
@@ -397,17 +756,25 @@ func (g *Generator) CollectInterfaceOptions(notations []*ast.Comment, validOps m
 
 func (g *Generator) CollectOptions(notations []*ast.Comment, validOps map[string]struct{}) (*structcopy.InputOption, error) {
 	inputOption := &structcopy.InputOption{
-		StructConverterFunc: "",
-		SkipFieldsMap:       map[string]bool{},
-		MatchFieldsMap:      map[string]string{},
-		MatchMethodsMap:     map[string]string{},
-		ConvertersMap:       map[string]string{},
+		StructConverterFunc:  "",
+		SkipFieldsMap:        map[string]bool{},
+		MatchFieldsMap:       map[string]string{},
+		MatchMethodsMap:      map[string]string{},
+		MatchSettersMap:      map[string]string{},
+		ConvertersMap:        map[string]string{},
+		InverseConvertersMap: map[string]string{},
+		LiteralsMap:          map[string]string{},
+		DefaultsMap:          map[string]string{},
+		NoPromoteMap:         map[string]bool{},
+		MergeZeroMap:         map[string]bool{},
+		EnumMapsMap:          map[string]string{},
 	}
 
 	for _, n := range notations {
 		m := reNotation.FindStringSubmatch(n.Text)
 		if m == nil || len(m) < 2 {
-			return nil, fmt.Errorf("invalid notation format %#v", m)
+			// plain comments, e.g. the doc of the interface
+			continue
 		}
 
 		var args []string
@@ -421,8 +788,8 @@ func (g *Generator) CollectOptions(notations []*ast.Comment, validOps map[string
 		}
 
 		switch m[1] {
-		case "structcopy-gen":
-			// do nothing
+		case "structcopy-gen", "receiver_type", "receiver_name", "receiver_field":
+			// collected by CollectInterfaceOptions
 		case "skip_field":
 			if len(args) < 1 {
 				return nil, fmt.Errorf("%v: needs <dst> args", g.fset.Position(n.Pos()))
@@ -454,6 +821,31 @@ func (g *Generator) CollectOptions(notations []*ast.Comment, validOps map[string
 			convertFunc := args[1]
 
 			inputOption.ConvertersMap[dst] = convertFunc
+			if len(args) > 2 {
+				inputOption.InverseConvertersMap[dst] = args[2]
+			}
+		case "enum_map":
+			if len(args) < 2 {
+				return nil, fmt.Errorf("%v: needs <dst> <src> args", g.fset.Position(n.Pos()))
+			}
+			dst := args[0]
+			src := args[1]
+
+			inputOption.EnumMapsMap[dst] = src
+		case "enum_pair":
+			if len(args) < 2 {
+				return nil, fmt.Errorf("%v: needs <dst_const> <src_const> args", g.fset.Position(n.Pos()))
+			}
+			dst := args[0]
+			src := args[1]
+
+			inputOption.EnumPairs = append(inputOption.EnumPairs, structcopy.EnumPair{Dst: dst, Src: src})
+		case "enum_default":
+			if len(args) < 1 {
+				return nil, fmt.Errorf("%v: needs <dst_const> args", g.fset.Position(n.Pos()))
+			}
+
+			inputOption.EnumDefault = args[0]
 		case "struct_conv":
 			if len(args) < 1 {
 				return nil, fmt.Errorf("%v: needs <convert_func> args", g.fset.Position(n.Pos()))
@@ -461,6 +853,99 @@ func (g *Generator) CollectOptions(notations []*ast.Comment, validOps map[string
 			convertFunc := args[0]
 
 			inputOption.StructConverterFunc = convertFunc
+		case "no_promote":
+			if len(args) < 1 {
+				return nil, fmt.Errorf("%v: needs <embedded_field> args", g.fset.Position(n.Pos()))
+			}
+			embeddedField := args[0]
+
+			inputOption.NoPromoteMap[embeddedField] = true
+		case "reverse":
+			if len(args) < 1 {
+				return nil, fmt.Errorf("%v: needs <method> args", g.fset.Position(n.Pos()))
+			}
+			if !isValidIdentifier(args[0]) {
+				return nil, fmt.Errorf("%v: reverse method name is invalid: %v", g.fset.Position(n.Pos()), args[0])
+			}
+			inputOption.ReverseMethod = args[0]
+		case "merge":
+			inputOption.Merge = true
+		case "merge_zero":
+			if len(args) < 1 {
+				return nil, fmt.Errorf("%v: needs <dst> args", g.fset.Position(n.Pos()))
+			}
+			inputOption.MergeZeroMap[args[0]] = true
+		case "preprocess":
+			if len(args) < 1 {
+				return nil, fmt.Errorf("%v: needs <func> args", g.fset.Position(n.Pos()))
+			}
+			inputOption.PreProcessFunc = args[0]
+		case "postprocess":
+			if len(args) < 1 {
+				return nil, fmt.Errorf("%v: needs <func> args", g.fset.Position(n.Pos()))
+			}
+			inputOption.PostProcessFunc = args[0]
+		case "match_rule":
+			rule, key, err := g.parseMatchRule(n, args)
+			if err != nil {
+				return nil, err
+			}
+			inputOption.MatchRule = rule
+			inputOption.MatchTagKey = key
+		case "name_match":
+			if len(args) < 1 {
+				return nil, fmt.Errorf("%v: needs <strategy> args", g.fset.Position(n.Pos()))
+			}
+			nameMatch, ok := structcopy.NewNameMatchFromValue(args[0])
+			if !ok {
+				return nil, fmt.Errorf("%v: name_match is invalid: %v", g.fset.Position(n.Pos()), args[0])
+			}
+			inputOption.NameMatch = nameMatch
+		case "strip_prefix":
+			if len(args) < 1 {
+				return nil, fmt.Errorf("%v: needs <prefix> args", g.fset.Position(n.Pos()))
+			}
+			inputOption.StripPrefixes = append(inputOption.StripPrefixes, args...)
+		case "strip_suffix":
+			if len(args) < 1 {
+				return nil, fmt.Errorf("%v: needs <suffix> args", g.fset.Position(n.Pos()))
+			}
+			inputOption.StripSuffixes = append(inputOption.StripSuffixes, args...)
+		case "use_getters":
+			inputOption.UseGetters = true
+		case "use_setters":
+			inputOption.UseSetters = true
+		case "strict":
+			inputOption.Strict = true
+		case "warn_unused_src":
+			inputOption.ReportUnusedSrc = structcopy.UnusedSrcReportWarn
+			if len(args) > 0 {
+				report, ok := structcopy.NewUnusedSrcReportFromValue(args[0])
+				if !ok {
+					return nil, fmt.Errorf("%v: warn_unused_src is invalid: %v", g.fset.Position(n.Pos()), args[0])
+				}
+				inputOption.ReportUnusedSrc = report
+			}
+		case "match_setter":
+			if len(args) < 2 {
+				return nil, fmt.Errorf("%v: needs <dst> <method> args", g.fset.Position(n.Pos()))
+			}
+			dst := args[0]
+			setter := args[1]
+
+			inputOption.MatchSettersMap[dst] = setter
+		case "literal", "default":
+			expr := reLiteral.FindStringSubmatch(m[2])
+			if len(args) < 2 || expr == nil {
+				return nil, fmt.Errorf("%v: needs <dst> <expr> args", g.fset.Position(n.Pos()))
+			}
+			dst := args[0]
+
+			if m[1] == "literal" {
+				inputOption.LiteralsMap[dst] = strings.TrimSpace(expr[1])
+			} else {
+				inputOption.DefaultsMap[dst] = strings.TrimSpace(expr[1])
+			}
 		default:
 			fmt.Printf("%v: unknown notation %v\n", g.fset.Position(n.Pos()), m[1])
 		}
@@ -469,6 +954,110 @@ func (g *Generator) CollectOptions(notations []*ast.Comment, validOps map[string
 	return inputOption, nil
 }
 
+// inheritOptions returns the options of a method given the options inherited
+// from its interface. The notations of the method override the ones of the
+// interface, and a field given a source by the method is no longer skipped.
+func inheritOptions(parent, child *structcopy.InputOption) *structcopy.InputOption {
+	inherit := func(parentMap, childMap map[string]string) map[string]string {
+		result := maps.Clone(parentMap)
+		if result == nil {
+			result = map[string]string{}
+		}
+		maps.Copy(result, childMap)
+		return result
+	}
+
+	result := &structcopy.InputOption{
+		SkipFieldsMap:        maps.Clone(parent.SkipFieldsMap),
+		MatchFieldsMap:       inherit(parent.MatchFieldsMap, child.MatchFieldsMap),
+		MatchMethodsMap:      inherit(parent.MatchMethodsMap, child.MatchMethodsMap),
+		MatchSettersMap:      inherit(parent.MatchSettersMap, child.MatchSettersMap),
+		ConvertersMap:        inherit(parent.ConvertersMap, child.ConvertersMap),
+		InverseConvertersMap: inherit(parent.InverseConvertersMap, child.InverseConvertersMap),
+		LiteralsMap:          inherit(parent.LiteralsMap, child.LiteralsMap),
+		DefaultsMap:          inherit(parent.DefaultsMap, child.DefaultsMap),
+		EnumMapsMap:          inherit(parent.EnumMapsMap, child.EnumMapsMap),
+		EnumPairs:            append(slices.Clone(parent.EnumPairs), child.EnumPairs...),
+		EnumDefault:          parent.EnumDefault,
+		NoPromoteMap:         maps.Clone(parent.NoPromoteMap),
+		StructConverterFunc:  child.StructConverterFunc,
+		PreProcessFunc:       child.PreProcessFunc,
+		PostProcessFunc:      child.PostProcessFunc,
+		ReverseMethod:        child.ReverseMethod,
+		Merge:                child.Merge,
+		MergeZeroMap:         child.MergeZeroMap,
+		MatchRule:            parent.MatchRule,
+		MatchTagKey:          parent.MatchTagKey,
+		NameMatch:            parent.NameMatch,
+		StripPrefixes:        parent.StripPrefixes,
+		StripSuffixes:        parent.StripSuffixes,
+		UseGetters:           parent.UseGetters || child.UseGetters,
+		UseSetters:           parent.UseSetters || child.UseSetters,
+		Strict:               parent.Strict || child.Strict,
+		ReportUnusedSrc:      parent.ReportUnusedSrc,
+	}
+	if result.SkipFieldsMap == nil {
+		result.SkipFieldsMap = map[string]bool{}
+	}
+	if result.NoPromoteMap == nil {
+		result.NoPromoteMap = map[string]bool{}
+	}
+	for _, m := range []map[string]string{child.MatchFieldsMap, child.MatchMethodsMap, child.MatchSettersMap, child.ConvertersMap, child.LiteralsMap, child.EnumMapsMap} {
+		for dst := range m {
+			delete(result.SkipFieldsMap, dst)
+		}
+	}
+	for dst := range child.ConvertersMap {
+		// the inverse of an inherited converter doesn't apply to the one of the method
+		if _, ok := child.InverseConvertersMap[dst]; !ok {
+			delete(result.InverseConvertersMap, dst)
+		}
+	}
+	maps.Copy(result.SkipFieldsMap, child.SkipFieldsMap)
+	maps.Copy(result.NoPromoteMap, child.NoPromoteMap)
+
+	if child.MatchRule != "" {
+		result.MatchRule = child.MatchRule
+		result.MatchTagKey = child.MatchTagKey
+	}
+	if child.EnumDefault != "" {
+		result.EnumDefault = child.EnumDefault
+	}
+	if child.ReportUnusedSrc != "" {
+		result.ReportUnusedSrc = child.ReportUnusedSrc
+	}
+	if child.NameMatch != "" {
+		result.NameMatch = child.NameMatch
+	}
+	if len(child.StripPrefixes) > 0 {
+		result.StripPrefixes = child.StripPrefixes
+	}
+	if len(child.StripSuffixes) > 0 {
+		result.StripSuffixes = child.StripSuffixes
+	}
+
+	return result
+}
+
+// parseMatchRule parses the args of the :match_rule notation,
+// e.g. "tag json", "name" or "none".
+func (g *Generator) parseMatchRule(n *ast.Comment, args []string) (structcopy.MatchRule, string, error) {
+	if len(args) < 1 {
+		return "", "", fmt.Errorf("%v: needs <rule> args", g.fset.Position(n.Pos()))
+	}
+	rule, ok := structcopy.NewMatchRuleFromValue(args[0])
+	if !ok {
+		return "", "", fmt.Errorf("%v: match_rule is invalid: %v", g.fset.Position(n.Pos()), args[0])
+	}
+	if rule != structcopy.MatchRuleTag {
+		return rule, "", nil
+	}
+	if len(args) < 2 {
+		return "", "", fmt.Errorf("%v: needs tag <key> args", g.fset.Position(n.Pos()))
+	}
+	return rule, args[1], nil
+}
+
 // isValidIdentifier checks if the given string is a valid identifier.
 func isValidIdentifier(id string) bool {
 	for i, r := range id {
@@ -484,50 +1073,6 @@ func (g *Generator) initDefaults() {
 	g.logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
 }
 
-func collectStructs(file *ast.File, store map[string]*ast.StructType, parsedStore map[string]*structcopy.Struct, pkgPath, rootPkgName string) {
-	packageName := file.Name.Name
-	for _, decl := range file.Decls {
-		gen, ok := decl.(*ast.GenDecl)
-		if !ok || gen.Tok != token.TYPE {
-			continue
-		}
-
-		for _, spec := range gen.Specs {
-			ts, ok := spec.(*ast.TypeSpec)
-			if !ok {
-				continue
-			}
-
-			// structPkgName := ""
-			// switch t := ts.Type.(type) {
-			// case *ast.Ident:
-			// 	structPkgName = packageName
-			// case *ast.SelectorExpr:
-			// 	pkgIdent, ok := t.X.(*ast.Ident)
-			// 	if ok {
-			// 		structPkgName = pkgIdent.Name
-			// 	}
-			// case *ast.StarExpr:
-			// }
-
-			if st, ok := ts.Type.(*ast.StructType); ok {
-				structPkgName := ""
-				if packageName != rootPkgName {
-					structPkgName = packageName
-				}
-
-				structTypeName := ts.Name.Name
-				structMapKey := structTypeName
-				if structPkgName != "" {
-					structMapKey = fmt.Sprintf("%s.%s", structPkgName, structTypeName)
-				}
-				store[ts.Name.Name] = st
-				parsedStore[structMapKey] = parseStruct(ts, structPkgName, pkgPath)
-			}
-		}
-	}
-}
-
 // ---- Helpers ----
 
 // extractTypeName unwraps *T, pkg.T, *pkg.T → "T"
@@ -551,151 +1096,3 @@ func stripType(t string) string {
 	}
 	return t
 }
-
-func parseStruct(typeSpec *ast.TypeSpec, pkgName string, rootPkgPath string) *structcopy.Struct {
-	st, ok := typeSpec.Type.(*ast.StructType)
-	if !ok {
-		return nil
-	}
-
-	result := structcopy.Struct{
-		PackagePath: rootPkgPath,
-		PkgName:     pkgName,
-		Type:        typeSpec.Name.Name,
-		Name:        typeSpec.Name.Name,
-	}
-
-	for _, field := range st.Fields.List {
-		typeName, pkgRef, isPtr, isSlice := parseFieldType(pkgName, field.Type)
-
-		for _, name := range field.Names { // handle embedded fields too later
-			result.Fields = append(result.Fields, structcopy.Field{
-				Name:       name.Name,
-				Type:       typeName,
-				PackageRef: pkgRef,
-				IsPointer:  isPtr,
-				IsSlice:    isSlice,
-			})
-		}
-	}
-
-	return &result
-}
-
-func parseFieldType(pkgName string, expr ast.Expr) (typeName string, pkg string, isPtr, isSlice bool) {
-	switch t := expr.(type) {
-	case *ast.Ident: // basic type
-		return t.Name, "", false, false
-	case *ast.SelectorExpr: // imported type
-		pkgIdent, ok := t.X.(*ast.Ident)
-		if !ok {
-			return t.Sel.Name, "", false, false
-		}
-		return t.Sel.Name, pkgIdent.Name, false, false
-	case *ast.StarExpr: // pointer type, includes pointer basic type and pointer imported type
-		name, pkg, _, isSliceInner := parseFieldType(pkgName, t.X)
-		return name, pkg, true, isSliceInner
-	case *ast.ArrayType:
-		name, pkg, isPtrInner, _ := parseFieldType("", t.Elt)
-		return name, pkg, isPtrInner, true
-	// case *ast.MapType: // Map type (e.g., "map[K]V")
-	// 	// info.Kind = structcopy.KindMap
-	// 	// Recursively analyze the key and value types
-	// 	// keyInfo := parseFieldType("", t.Key)
-	// 	// valueInfo := parseFieldType("", t.Value)
-	// 	// info.Key = &keyInfo
-	// 	// info.Value = &valueInfo
-	// case *ast.StructType: // Inline struct definition
-	// 	// info.Kind = structcopy.KindStruct
-	// 	// info.Fields = extractStructFields(t)
-	// case *ast.InterfaceType: // Interface definition (e.g., "interface{}")
-	// 	// info.Kind = structcopy.KindInterface
-	// 	// We could analyze methods here, but for simplicity, we just mark the kind.
-	default:
-		return fmt.Sprintf("%T", expr), "", false, false
-	}
-}
-
-func parseMethodParams(pkgName string, field *ast.Field, structs map[string]*structcopy.Struct) []structcopy.MethodParam {
-	var results []structcopy.MethodParam
-
-	paramName := "src"
-	// (src *entity.User)
-	for _, name := range field.Names {
-		paramName = name.Name
-	}
-	typeName, pkgRef, isPointer, isSlice := parseFieldType(pkgName, field.Type)
-
-	key := typeName
-	if pkgRef != "" {
-		key = pkgRef + "." + typeName
-	}
-
-	isStruct := false
-	structDef, ok := structs[key]
-	if ok {
-		isStruct = true
-	}
-
-	fullType := key
-	if isPointer {
-		fullType = fmt.Sprintf("*%s", key)
-	}
-	param := structcopy.MethodParam{
-		Name:                paramName,
-		Type:                typeName,
-		PointerlessFullType: key,
-		FullType:            fullType,
-		PackageRef:          pkgRef,
-		IsStruct:            isStruct,
-		IsPointer:           isPointer,
-		IsSlice:             isSlice,
-		StructDef:           structDef, // link to collected struct if exists
-	}
-
-	results = append(results, param)
-
-	return results
-}
-
-func parseMethodResults(pkgName string, field *ast.Field, structs map[string]*structcopy.Struct) []structcopy.MethodResult {
-	var results []structcopy.MethodResult
-
-	resultName := "dst"
-	// (dst *dto.UserDTO)
-	for _, name := range field.Names {
-		resultName = name.Name
-	}
-	typeName, pkgRef, isPtr, isSlice := parseFieldType(pkgName, field.Type)
-
-	key := typeName
-	if pkgRef != "" {
-		key = pkgRef + "." + typeName
-	}
-
-	isStruct := false
-	structDef, ok := structs[key]
-	if ok {
-		isStruct = true
-	}
-
-	fullType := key
-	if isPtr {
-		fullType = fmt.Sprintf("*%s", key)
-	}
-	param := structcopy.MethodResult{
-		Name:                resultName,
-		Type:                typeName,
-		PointerlessFullType: key,
-		FullType:            fullType,
-		PackageRef:          pkgRef,
-		IsStruct:            isStruct,
-		IsPointer:           isPtr,
-		IsSlice:             isSlice,
-		StructDef:           structDef, // link to collected struct if exists
-	}
-
-	results = append(results, param)
-
-	return results
-}
@@ -1,6 +1,10 @@
 package gen
 
-import "log/slog"
+import (
+	"log/slog"
+
+	"github.com/structcopy/structcopy-gen/pkg/structcopy"
+)
 
 // GeneratorOption is option for Generator.
 type GeneratorOption func(g *Generator)
@@ -33,6 +37,20 @@ func WithLogPath(log string) GeneratorOption {
 	}
 }
 
+// WithStrict fails the generation on unmatched destination fields.
+func WithStrict(strict bool) GeneratorOption {
+	return func(g *Generator) {
+		g.strict = strict
+	}
+}
+
+// WithReportUnusedSrc reports the source fields left unused by the methods.
+func WithReportUnusedSrc(report structcopy.UnusedSrcReport) GeneratorOption {
+	return func(g *Generator) {
+		g.reportUnusedSrc = report
+	}
+}
+
 // WithLogEnabled sets output path.
 func WithLogEnabled(logs bool) GeneratorOption {
 	return func(g *Generator) {
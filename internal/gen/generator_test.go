@@ -0,0 +1,121 @@
+package gen
+
+import (
+	"flag"
+	"go/ast"
+	"go/token"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/structcopy/structcopy-gen/internal/load"
+	"golang.org/x/tools/go/packages"
+)
+
+var update = flag.Bool("update", false, "update the golden files of testdata")
+
+// generate runs the generator on the input file inp, and returns the code it
+// generates into "<inp>.gen.go" without writing it.
+func generate(t *testing.T, inp string, opts ...GeneratorOption) (string, error) {
+	t.Helper()
+
+	out := strings.TrimSuffix(inp, ".go") + ".gen.go"
+	var content []byte
+	err := load.LoadPackage(inp, out, func(pkg *packages.Package, fset *token.FileSet, file *ast.File) error {
+		opts = append([]GeneratorOption{
+			WithInputPath(inp),
+			WithOutputPath(out),
+			WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		}, opts...)
+		g, err := NewGenerator(pkg, fset, file, opts...)
+		if err != nil {
+			return err
+		}
+		content, err = g.Generate(out, false, true)
+		return err
+	})
+	return string(content), err
+}
+
+// assertGolden generates the code of the input file inp and compares it to
+// the generated file next to it, which is rewritten with -update.
+func assertGolden(t *testing.T, inp string, opts ...GeneratorOption) {
+	t.Helper()
+
+	got, err := generate(t, inp, opts...)
+	if err != nil {
+		t.Fatalf("generate %s: %v", inp, err)
+	}
+
+	golden := strings.TrimSuffix(inp, ".go") + ".gen.go"
+	if *update {
+		if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("generated code of %s differs from %s, run go test -update to see the diff:\n%s", inp, golden, got)
+	}
+}
+
+// assertError generates the code of the input file inp and checks that the
+// generation fails with an error containing want.
+func assertError(t *testing.T, inp string, want string, opts ...GeneratorOption) {
+	t.Helper()
+
+	_, err := generate(t, inp, opts...)
+	if err == nil {
+		t.Fatalf("generate %s: got no error, want %q", inp, want)
+	}
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("generate %s: got error %q, want %q", inp, err, want)
+	}
+}
+
+func TestGenerateExamples(t *testing.T) {
+	for _, inp := range []string{
+		"../../examples/internal/example/structcopy-gen.go",
+		"../../examples/internal/standalone/structcopy-gen.go",
+	} {
+		t.Run(filepath.Base(filepath.Dir(inp)), func(t *testing.T) {
+			assertGolden(t, inp)
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "receiver_field", want: "needs <name> <type> args"},
+		{name: "conv_unresolved", want: "Strr can't be resolved"},
+		{name: "conv_no_arg", want: "Now takes no argument, use :literal CreatedAt Now()"},
+		{name: "conv_param", want: "ParseAmount takes string, which src.Total of type int64 can't be passed to"},
+		{name: "type_mismatch", want: "src.Total of type string can't be converted to dst.Total of type int64"},
+		{name: "nested_args", want: "AddressToAddressDTO takes 2 additional arguments, but UserToUserDTO has 1"},
+		{name: "strict", want: "no match for dst.Phone, add :match_field or :skip_field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertError(t, filepath.Join("testdata", "errors", tt.name+".go"), tt.want)
+		})
+	}
+}
+
+func TestGenerateStrictOption(t *testing.T) {
+	inp := filepath.Join("testdata", "errors", "unmatched.go")
+
+	if _, err := generate(t, inp); err != nil {
+		t.Fatalf("generate %s: %v", inp, err)
+	}
+	assertError(t, inp, "no match for dst.Phone", WithStrict(true))
+}
@@ -0,0 +1,66 @@
+package gen
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/structcopy/structcopy-gen/pkg/structcopy"
+)
+
+// normalizeName returns the key used to compare the field name following the
+// name match strategy and the strip rules of the method. Names sharing the
+// same key are paired.
+func normalizeName(name string, method structcopy.Method) string {
+	for _, prefix := range method.StripPrefixes {
+		if s, ok := strings.CutPrefix(name, prefix); ok && s != "" {
+			name = s
+			break
+		}
+	}
+	for _, suffix := range method.StripSuffixes {
+		if s, ok := strings.CutSuffix(name, suffix); ok && s != "" {
+			name = s
+			break
+		}
+	}
+
+	switch method.NameMatch {
+	case structcopy.NameMatchIgnoreCase:
+		return strings.ToLower(name)
+	case structcopy.NameMatchAcronym:
+		// "UserID" and "UserId" both become "UserId"
+		var sb strings.Builder
+		for _, word := range splitWords(name) {
+			sb.WriteString(strings.ToUpper(word[:1]))
+			sb.WriteString(strings.ToLower(word[1:]))
+		}
+		return sb.String()
+	case structcopy.NameMatchSnakeCamel:
+		// "User_ID", "UserID" and "user_id" all become "user_id"
+		return strings.ToLower(strings.Join(splitWords(name), "_"))
+	default:
+		return name
+	}
+}
+
+// splitWords splits a snake or camel case name into its words, keeping
+// acronyms together, e.g. "URLPath" into "URL" and "Path".
+func splitWords(name string) []string {
+	var words []string
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' }) {
+		runes := []rune(part)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			lowerToUpper := !unicode.IsUpper(runes[i-1]) && unicode.IsUpper(runes[i])
+			// the last letter of an acronym starts the next word
+			acronymEnd := unicode.IsUpper(runes[i-1]) && unicode.IsUpper(runes[i]) &&
+				i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if lowerToUpper || acronymEnd {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
@@ -0,0 +1,633 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/structcopy/structcopy-gen/pkg/structcopy"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// initImports registers the imports of the input file so that generated type
+// expressions reuse their names (or aliases).
+func (g *Generator) initImports() {
+	g.importNames = map[string]string{}
+	g.usedImportNames = map[string]bool{}
+
+	for _, imp := range g.file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := ""
+		if imp.Name != nil {
+			name = imp.Name.Name
+		} else if p, ok := g.pkg.Imports[importPath]; ok {
+			name = p.Name
+		}
+		if name == "" || name == "_" {
+			continue
+		}
+
+		g.importNames[importPath] = name
+		g.usedImportNames[name] = true
+	}
+}
+
+// qualifier returns the name used to refer to the given package in the
+// generated file. Packages which are not imported by the input file yet are
+// added to the imports, with an alias if their name is already taken.
+func (g *Generator) qualifier(p *types.Package) string {
+	if p == nil || p.Path() == g.pkg.PkgPath {
+		return ""
+	}
+
+	if name, ok := g.importNames[p.Path()]; ok {
+		if name == "." {
+			return ""
+		}
+		return name
+	}
+
+	name := p.Name()
+	for i := 2; g.usedImportNames[name]; i++ {
+		name = fmt.Sprintf("%s%d", p.Name(), i)
+	}
+
+	imp := structcopy.Import{
+		Path: strconv.Quote(p.Path()),
+	}
+	if name != p.Name() {
+		imp.Name = name
+	}
+	g.spec.Imports = append(g.spec.Imports, imp)
+
+	g.importNames[p.Path()] = name
+	g.usedImportNames[name] = true
+
+	return name
+}
+
+// typeString returns the type expression of t as written in the generated file.
+func (g *Generator) typeString(t types.Type) string {
+	return types.TypeString(t, g.qualifier)
+}
+
+// derefType unwraps aliases and a single pointer level.
+func derefType(t types.Type) (types.Type, bool) {
+	t = types.Unalias(t)
+	if p, ok := t.(*types.Pointer); ok {
+		return types.Unalias(p.Elem()), true
+	}
+	return t, false
+}
+
+// elemType unwraps aliases and a single slice level.
+func elemType(t types.Type) (types.Type, bool) {
+	t = types.Unalias(t)
+	if s, ok := t.(*types.Slice); ok {
+		return types.Unalias(s.Elem()), true
+	}
+	return t, false
+}
+
+// lookupStruct resolves the struct definition of t, looking through aliases,
+// type definitions and a single pointer level. It returns nil when t is not
+// a struct type. Results are cached by the fully qualified type name, so
+// structs of different packages sharing the same package name never collide.
+func (g *Generator) lookupStruct(t types.Type) *structcopy.Struct {
+	t, _ = derefType(t)
+
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	key := types.TypeString(t, nil)
+	if s, ok := g.structs[key]; ok {
+		return s
+	}
+
+	result := &structcopy.Struct{
+		Type: g.typeString(t),
+		Typ:  t,
+	}
+	if named, ok := t.(*types.Named); ok {
+		obj := named.Obj()
+		result.Name = obj.Name()
+		if obj.Pkg() != nil {
+			result.PackagePath = obj.Pkg().Path()
+			result.PkgName = g.qualifier(obj.Pkg())
+		}
+	}
+	g.structs[key] = result
+
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		field := g.parseField(v)
+		field.Tag = st.Tag(i)
+		result.Fields = append(result.Fields, field)
+	}
+
+	return result
+}
+
+// parseField converts a struct field into a structcopy.Field.
+func (g *Generator) parseField(v *types.Var) structcopy.Field {
+	typeName, pkgRef, isPtr, isSlice := g.parseFieldType(v.Type())
+
+	return structcopy.Field{
+		Name:       v.Name(),
+		Path:       v.Name(),
+		Kind:       string(typeKind(v.Type())),
+		Type:       typeName,
+		FullType:   g.typeString(v.Type()),
+		IsStruct:   g.lookupStruct(v.Type()) != nil,
+		IsPointer:  isPtr,
+		IsSlice:    isSlice,
+		PackageRef: pkgRef,
+		Exported:   v.Exported() || v.Pkg() == g.pkg.Types,
+		Embedded:   v.Embedded(),
+		Typ:        v.Type(),
+	}
+}
+
+// structFields holds the fields of a struct after promoting the fields of
+// its embedded structs.
+type structFields struct {
+	Typ    types.Type // type of the struct the fields belong to
+	Fields []structcopy.Field
+	// Ambiguous maps a promoted field name declared more than once at the
+	// shallowest depth to the selector paths of its declarations.
+	Ambiguous map[string][]string
+	// EmbeddedPtrs maps the selector paths of pointer embedded structs to
+	// their struct types.
+	EmbeddedPtrs map[string]types.Type
+}
+
+// promoteFields returns the fields of st with the fields of embedded structs
+// promoted following Go's selector rules: a field at a shallower depth hides
+// fields of the same name at deeper depths, and a name declared more than
+// once at the same depth is ambiguous. Embedded fields listed in noPromote,
+// and embedded types without accessible fields, are kept as a unit.
+func (g *Generator) promoteFields(st *structcopy.Struct, noPromote map[string]bool) *structFields {
+	type embedded struct {
+		st    *structcopy.Struct
+		path  string
+		ptrs  []string
+		index []int
+	}
+
+	result := &structFields{
+		Typ:          st.Typ,
+		Ambiguous:    map[string][]string{},
+		EmbeddedPtrs: map[string]types.Type{},
+	}
+	seen := map[string]bool{}
+	fieldIndexes := map[string][]int{}
+	// visited holds the structs expanded at a shallower depth, which would
+	// otherwise loop on recursively embedded types.
+	visited := map[*structcopy.Struct]bool{st: true}
+
+	current := []embedded{{st: st}}
+	for len(current) > 0 {
+		var next []embedded
+		var names []string
+		found := map[string][]structcopy.Field{}
+		indexes := map[string][]int{}
+		promoted := map[string]bool{}
+		expanded := map[*structcopy.Struct]bool{}
+
+		for _, e := range current {
+			for i, field := range e.st.Fields {
+				field.EmbeddedPtrs = e.ptrs
+				index := append(append([]int{}, e.index...), i)
+
+				if field.Embedded && !noPromote[field.Name] {
+					if embeddedStruct := g.lookupStruct(field.Typ); embeddedStruct != nil &&
+						!visited[embeddedStruct] && g.hasAccessibleFields(embeddedStruct) {
+						expanded[embeddedStruct] = true
+						ptrs := e.ptrs
+						if base, isPtr := derefType(field.Typ); isPtr {
+							ptrs = append(append([]string{}, e.ptrs...), e.path+field.Name)
+							result.EmbeddedPtrs[e.path+field.Name] = base
+						}
+						next = append(next, embedded{st: embeddedStruct, path: e.path + field.Name + ".", ptrs: ptrs, index: index})
+						promoted[e.path+field.Name] = true
+					}
+				}
+
+				if _, ok := found[field.Name]; !ok {
+					names = append(names, field.Name)
+				}
+				field.Path = e.path + field.Name
+				found[field.Name] = append(found[field.Name], field)
+				indexes[field.Path] = index
+			}
+		}
+
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			fields := found[name]
+			if len(fields) > 1 {
+				for _, field := range fields {
+					result.Ambiguous[name] = append(result.Ambiguous[name], field.Path)
+				}
+				continue
+			}
+			// promoted embedded structs are replaced by their fields
+			if promoted[fields[0].Path] {
+				continue
+			}
+			result.Fields = append(result.Fields, fields[0])
+			fieldIndexes[fields[0].Path] = indexes[fields[0].Path]
+		}
+
+		for embeddedStruct := range expanded {
+			visited[embeddedStruct] = true
+		}
+		current = next
+	}
+
+	// keep promoted fields at the position of their embedded struct
+	slices.SortStableFunc(result.Fields, func(a, b structcopy.Field) int {
+		return slices.Compare(fieldIndexes[a.Path], fieldIndexes[b.Path])
+	})
+
+	return result
+}
+
+// hasAccessibleFields reports whether st has a field accessible from the
+// generated package.
+func (g *Generator) hasAccessibleFields(st *structcopy.Struct) bool {
+	for _, field := range st.Fields {
+		if field.Exported {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFieldType returns the bare type name and package reference of t,
+// unwrapping a slice and a pointer level.
+func (g *Generator) parseFieldType(t types.Type) (typeName string, pkg string, isPtr, isSlice bool) {
+	t, isSlice = elemType(t)
+	t, isPtr = derefType(t)
+
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj().Name(), g.qualifier(named.Obj().Pkg()), isPtr, isSlice
+	}
+	return g.typeString(t), "", isPtr, isSlice
+}
+
+// lookupFunc resolves the signature of the function referred to by name,
+// either declared in the generated package, qualified by the name of an
+// imported package, e.g. "strings.ToUpper", or a method or a func field of a
+// field of the receiver struct, e.g. "c.clock.Now". Other expressions of a
+// function, e.g. an instantiated generic function, are evaluated in the scope
+// of the input file. It returns nil when the function can't be resolved.
+func (g *Generator) lookupFunc(name string) *types.Signature {
+	if path, ok := strings.CutPrefix(name, "c."); ok {
+		fieldName, methodName, _ := strings.Cut(path, ".")
+		typ, ok := g.receiverFields[fieldName]
+		if !ok {
+			return nil
+		}
+		if methodName == "" {
+			sig, _ := typ.Underlying().(*types.Signature)
+			return sig
+		}
+		return g.lookupMethod(typ, methodName)
+	}
+
+	expr := name
+	scope := g.pkg.Types.Scope()
+	if pkgName, funcName, ok := strings.Cut(name, "."); ok {
+		scope = nil
+		for _, imp := range g.pkg.Types.Imports() {
+			if g.importNames[imp.Path()] == pkgName {
+				scope = imp.Scope()
+				break
+			}
+		}
+		if scope == nil {
+			return g.evalFunc(expr)
+		}
+		name = funcName
+	}
+
+	obj := scope.Lookup(name)
+	switch obj.(type) {
+	case *types.Func, *types.Var:
+		sig, _ := obj.Type().Underlying().(*types.Signature)
+		return sig
+	}
+	return g.evalFunc(expr)
+}
+
+// evalFunc returns the signature of the function expression expr evaluated in
+// the scope of the input file, e.g. "Convert[int]" or "defaultClock.Now". It
+// returns nil when expr isn't a function.
+func (g *Generator) evalFunc(expr string) *types.Signature {
+	tv, err := types.Eval(g.fset, g.pkg.Types, g.file.Name.Pos(), expr)
+	if err != nil || !tv.IsValue() {
+		return nil
+	}
+	sig, _ := tv.Type.Underlying().(*types.Signature)
+	return sig
+}
+
+// lookupMethod resolves the signature of the method name of t, including the
+// methods declared with a pointer receiver. It returns nil when t has no such
+// method.
+func (g *Generator) lookupMethod(t types.Type, name string) *types.Signature {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, g.pkg.Types, name)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil
+	}
+	return fn.Type().(*types.Signature)
+}
+
+// tupleTypes returns the types of the variables of t.
+func tupleTypes(t *types.Tuple) []types.Type {
+	result := make([]types.Type, 0, t.Len())
+	for i := 0; i < t.Len(); i++ {
+		result = append(result, t.At(i).Type())
+	}
+	return result
+}
+
+// isErrorType reports whether t is the error interface.
+func isErrorType(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// retError reports whether sig returns a value and an error.
+func retError(sig *types.Signature) bool {
+	return sig.Results().Len() == 2 && isErrorType(sig.Results().At(1).Type())
+}
+
+// isDstVarArg reports whether sig takes a pointer to the destination struct
+// followed by the source struct, and returns nothing or an error.
+func isDstVarArg(sig *types.Signature) bool {
+	if sig.Params().Len() < 2 {
+		return false
+	}
+	switch results := sig.Results(); {
+	case results.Len() == 0:
+	case results.Len() == 1 && isErrorType(results.At(0).Type()):
+	default:
+		return false
+	}
+
+	dst, isPtr := derefType(sig.Params().At(0).Type())
+	if !isPtr {
+		return false
+	}
+	src, _ := derefType(sig.Params().At(1).Type())
+	_, dstIsStruct := dst.Underlying().(*types.Struct)
+	_, srcIsStruct := src.Underlying().(*types.Struct)
+	return dstIsStruct && srcIsStruct
+}
+
+// declaresError reports whether the last result of m is an error.
+func declaresError(m structcopy.Method) bool {
+	return len(m.Results) > 0 && isErrorType(m.Results[len(m.Results)-1].Typ)
+}
+
+// retErrorOnly reports whether sig returns an error only.
+func retErrorOnly(sig *types.Signature) bool {
+	return sig.Results().Len() == 1 && isErrorType(sig.Results().At(0).Type())
+}
+
+// exportedName returns name with its first letter in upper case, e.g. "Email"
+// for the field "email".
+func exportedName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+// checkExpr type-checks the Go expression expr in the scope of the input file,
+// and checks that its value can be assigned to a variable of type t. expr may
+// refer to the fields of the receiver struct, e.g. "c.clock.Now()".
+func (g *Generator) checkExpr(expr string, t types.Type) error {
+	evalExpr, fields, err := g.receiverFieldsExpr(expr)
+	if err != nil {
+		return err
+	}
+	tv, err := types.Eval(g.fset, g.pkg.Types, g.file.Name.Pos(), evalExpr)
+	if err != nil {
+		// the error is about the receiver fields, not their values
+		return fmt.Errorf("%s: %s", expr, fields.Replace(err.Error()))
+	}
+	if !tv.IsValue() {
+		return fmt.Errorf("%s is not a value", expr)
+	}
+	if !types.AssignableTo(tv.Type, t) {
+		return fmt.Errorf("%s of type %s can't be assigned to %s", expr, g.typeString(tv.Type), g.typeString(t))
+	}
+	return nil
+}
+
+// receiverFieldsExpr returns expr with the fields of the receiver struct, e.g.
+// "c.clock", replaced by a value of their type, e.g. "(*new(Clock))", since the
+// receiver isn't declared in the scope of the input file. The returned replacer
+// turns the values back into the fields.
+func (g *Generator) receiverFieldsExpr(expr string) (string, *strings.Replacer, error) {
+	if len(g.receiverFields) == 0 {
+		return expr, strings.NewReplacer(), nil
+	}
+	node, err := parser.ParseExpr(expr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var oldnew []string
+
+	node = astutil.Apply(node, func(cursor *astutil.Cursor) bool {
+		sel, ok := cursor.Node().(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if ident, ok := sel.X.(*ast.Ident); !ok || ident.Name != "c" {
+			return true
+		}
+		typ, ok := g.receiverFields[sel.Sel.Name]
+		if !ok {
+			return true
+		}
+		valueExpr := fmt.Sprintf("(*new(%s))", g.typeString(typ))
+		value, err := parser.ParseExpr(valueExpr)
+		if err != nil {
+			return true
+		}
+		cursor.Replace(value)
+		oldnew = append(oldnew, valueExpr, "c."+sel.Sel.Name)
+		return false
+	}, nil).(ast.Expr)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), node); err != nil {
+		return "", nil, err
+	}
+	return buf.String(), strings.NewReplacer(oldnew...), nil
+}
+
+// evalType resolves the type expression expr, e.g. "*time.Location", in the
+// scope of the generated package.
+func (g *Generator) evalType(expr string) (types.Type, error) {
+	tv, err := types.Eval(g.fset, g.pkg.Types, g.file.Name.Pos(), expr)
+	if err != nil {
+		return nil, err
+	}
+	if !tv.IsType() {
+		return nil, fmt.Errorf("%s is not a type", expr)
+	}
+	return tv.Type, nil
+}
+
+// zeroCond returns the condition of expr of type t being its zero value, e.g.
+// `dst.Name == ""`, or being set when set is true, e.g. `src.Name != ""`.
+// Structs are checked by their IsZero method, e.g. `!src.When.IsZero()`, as
+// comparing them, e.g. time.Time, doesn't tell whether they're set. It returns
+// false when t can't be checked.
+func (g *Generator) zeroCond(expr string, t types.Type, set bool) (string, bool) {
+	op, not := "==", ""
+	if set {
+		op, not = "!=", "!"
+	}
+
+	zero := ""
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			zero = "false"
+		case u.Info()&types.IsString != 0:
+			zero = `""`
+		case u.Info()&types.IsNumeric != 0:
+			zero = "0"
+		case u.Kind() == types.UnsafePointer:
+			zero = "nil"
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Signature, *types.Interface, *types.Chan:
+		zero = "nil"
+	case *types.Struct:
+		if g.hasIsZero(t) {
+			return not + expr + ".IsZero()", true
+		}
+	case *types.Array:
+		if g.hasIsZero(t) {
+			return not + expr + ".IsZero()", true
+		}
+		if types.Comparable(t) {
+			zero = fmt.Sprintf("(%s{})", g.typeString(t))
+		}
+	}
+	if zero == "" {
+		return "", false
+	}
+	return fmt.Sprintf("%s %s %s", expr, op, zero), true
+}
+
+// hasIsZero reports whether t has the method "IsZero() bool", e.g. time.Time.
+func (g *Generator) hasIsZero(t types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, g.pkg.Types, "IsZero")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	return sig.Params().Len() == 0 && sig.Results().Len() == 1 &&
+		types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool])
+}
+
+// typeKind returns the kind of t.
+func typeKind(t types.Type) structcopy.TypeKind {
+	switch t.Underlying().(type) {
+	case *types.Basic:
+		return structcopy.KindBasic
+	case *types.Struct:
+		return structcopy.KindStruct
+	case *types.Interface:
+		return structcopy.KindInterface
+	case *types.Slice, *types.Array:
+		return structcopy.KindSlice
+	case *types.Pointer:
+		return structcopy.KindPointer
+	case *types.Map:
+		return structcopy.KindMap
+	default:
+		return structcopy.KindUnknown
+	}
+}
+
+// parseMethodParam converts a method parameter into a structcopy.MethodParam.
+func (g *Generator) parseMethodParam(v *types.Var, defaultName string) structcopy.MethodParam {
+	name := v.Name()
+	if name == "" || name == "_" {
+		name = defaultName
+	}
+
+	typeName, pkgRef, isPtr, isSlice := g.parseFieldType(v.Type())
+
+	elem, _ := elemType(v.Type())
+	pointerless, _ := derefType(elem)
+	structDef := g.lookupStruct(elem)
+
+	return structcopy.MethodParam{
+		Name:                name,
+		Kind:                string(typeKind(v.Type())),
+		Type:                typeName,
+		PointerlessFullType: g.typeString(pointerless),
+		FullType:            g.typeString(elem),
+		PackageRef:          pkgRef,
+		IsStruct:            structDef != nil,
+		IsPointer:           isPtr,
+		IsSlice:             isSlice,
+		StructDef:           structDef, // link to collected struct if exists
+		Typ:                 v.Type(),
+	}
+}
+
+// parseVariable converts an additional method parameter into a structcopy.Variable.
+func (g *Generator) parseVariable(v *types.Var, defaultName string) structcopy.Variable {
+	name := v.Name()
+	if name == "" || name == "_" {
+		name = defaultName
+	}
+
+	t, isPtr := derefType(v.Type())
+	external := false
+	if named, ok := t.(*types.Named); ok {
+		external = named.Obj().Pkg() != nil && named.Obj().Pkg() != g.pkg.Types
+	}
+
+	return structcopy.Variable{
+		Name:     name,
+		Type:     g.typeString(t),
+		Pointer:  isPtr,
+		External: external,
+	}
+}
+
+// parseMethodResult converts a method result into a structcopy.MethodResult.
+func (g *Generator) parseMethodResult(v *types.Var, defaultName string) structcopy.MethodResult {
+	return structcopy.MethodResult(g.parseMethodParam(v, defaultName))
+}
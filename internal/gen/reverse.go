@@ -0,0 +1,153 @@
+package gen
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/structcopy/structcopy-gen/pkg/structcopy"
+)
+
+// reverseMethod is an inverse method to synthesize from the :reverse notation
+// of the forward method.
+type reverseMethod struct {
+	name      string
+	forward   structcopy.Method
+	signature *types.Signature
+	options   *structcopy.InputOption
+	pos       token.Position // pos is the position of the :reverse notation
+}
+
+// reverseSignature returns the signature of the inverse of a method, with the
+// types of the source and the destination swapped. The additional parameters
+// and the error result are kept.
+func reverseSignature(sig *types.Signature) (*types.Signature, error) {
+	params := slices.Collect(sig.Params().Variables())
+	results := slices.Collect(sig.Results().Variables())
+
+	if isDstVarArg(sig) {
+		dst, src := params[0], params[1]
+		srcTyp, _ := derefType(src.Type())
+		params[0] = types.NewParam(dst.Pos(), dst.Pkg(), dst.Name(), types.NewPointer(srcTyp))
+		params[1] = types.NewParam(src.Pos(), src.Pkg(), src.Name(), dst.Type())
+	} else {
+		if len(params) == 0 || len(results) == 0 {
+			return nil, errors.New("the method needs a source and a destination")
+		}
+		src, dst := params[0], results[0]
+		params[0] = types.NewParam(src.Pos(), src.Pkg(), src.Name(), dst.Type())
+		results[0] = types.NewParam(dst.Pos(), dst.Pkg(), dst.Name(), src.Type())
+	}
+
+	return types.NewSignatureType(nil, nil, nil, types.NewTuple(params...), types.NewTuple(results...), false), nil
+}
+
+// isReverseSignature reports whether declared is the signature sig of an
+// inverse method, which may also return an error when sig doesn't, e.g. when
+// the inverse func of a :conv fails.
+func isReverseSignature(declared, sig *types.Signature) bool {
+	if types.Identical(declared, sig) {
+		return true
+	}
+	results := slices.Collect(sig.Results().Variables())
+	if len(results) > 0 && isErrorType(results[len(results)-1].Type()) {
+		return false
+	}
+	results = append(results, types.NewParam(token.NoPos, nil, "err", types.Universe.Lookup("error").Type()))
+	return types.Identical(declared, types.NewSignatureType(nil, nil, nil, sig.Params(), types.NewTuple(results...), false))
+}
+
+// reverseOptions returns the notations of the inverse of a method: the
+// :match_field pairs are swapped, the source fields of the skipped fields are
+// skipped, the converters are replaced by their inverse, and the :enum_map and
+// :enum_pair pairs are swapped. reverseNames maps
+// the methods of the interface to their inverse, for :struct_conv. The
+// notations which can't be inverted, e.g. :match_method or :literal, are
+// dropped, as well as the converters of fields the destination of forward
+// doesn't have, e.g. inherited from the interface.
+func (g *Generator) reverseOptions(
+	forward structcopy.Method,
+	options *structcopy.InputOption,
+	reverseNames map[string]string,
+) (*structcopy.InputOption, error) {
+	result := &structcopy.InputOption{
+		SkipFieldsMap:        map[string]bool{},
+		MatchFieldsMap:       map[string]string{},
+		MatchMethodsMap:      map[string]string{},
+		MatchSettersMap:      map[string]string{},
+		ConvertersMap:        map[string]string{},
+		InverseConvertersMap: map[string]string{},
+		LiteralsMap:          map[string]string{},
+		DefaultsMap:          map[string]string{},
+		EnumMapsMap:          map[string]string{},
+		NoPromoteMap:         maps.Clone(options.NoPromoteMap),
+		MatchRule:            options.MatchRule,
+		MatchTagKey:          options.MatchTagKey,
+		NameMatch:            options.NameMatch,
+		StripPrefixes:        options.StripPrefixes,
+		StripSuffixes:        options.StripSuffixes,
+		Strict:               options.Strict,
+		ReportUnusedSrc:      options.ReportUnusedSrc,
+	}
+
+	// srcName returns the source field paired with the destination field dst
+	srcName := func(dst string) string {
+		if src, ok := options.MatchFieldsMap[dst]; ok {
+			return src
+		}
+		return dst
+	}
+
+	for _, dst := range slices.Sorted(maps.Keys(options.MatchFieldsMap)) {
+		src := options.MatchFieldsMap[dst]
+		if other, ok := result.MatchFieldsMap[src]; ok {
+			return nil, fmt.Errorf("fields %s and %s both match %s, it can't be inverted", other, dst, src)
+		}
+		result.MatchFieldsMap[src] = dst
+	}
+	for dst := range options.SkipFieldsMap {
+		result.SkipFieldsMap[srcName(dst)] = true
+	}
+	var dstFields *structFields
+	if forward.FirstResult.StructDef != nil && !forward.FirstResult.IsSlice {
+		dstFields = g.promoteFields(forward.FirstResult.StructDef, options.NoPromoteMap)
+	}
+	for _, dst := range slices.Sorted(maps.Keys(options.ConvertersMap)) {
+		name, _, _ := strings.Cut(dst, ".")
+		if dstFields == nil || !slices.ContainsFunc(dstFields.Fields, func(fi structcopy.Field) bool { return fi.Name == name }) {
+			continue
+		}
+		inverse, ok := options.InverseConvertersMap[dst]
+		if !ok {
+			return nil, fmt.Errorf("conv of %s needs an inverse func, e.g. :conv %s %s <inverse_func>",
+				dst, dst, options.ConvertersMap[dst])
+		}
+		result.ConvertersMap[srcName(dst)] = inverse
+	}
+	for dst, src := range options.EnumMapsMap {
+		result.EnumMapsMap[src] = dst
+	}
+	// a constant paired with several ones can't be inverted
+	pairs := map[string]int{}
+	for _, pair := range options.EnumPairs {
+		pairs[pair.Dst]++
+	}
+	for _, pair := range options.EnumPairs {
+		if pairs[pair.Dst] == 1 {
+			result.EnumPairs = append(result.EnumPairs, structcopy.EnumPair{Dst: pair.Src, Src: pair.Dst})
+		}
+	}
+	if options.StructConverterFunc != "" {
+		reverse, ok := reverseNames[options.StructConverterFunc]
+		if !ok {
+			return nil, fmt.Errorf("struct_conv %s has no :reverse", options.StructConverterFunc)
+		}
+		result.StructConverterFunc = reverse
+	}
+
+	return result, nil
+}
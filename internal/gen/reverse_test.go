@@ -0,0 +1,30 @@
+package gen
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestReverse(t *testing.T) {
+	for _, dir := range []string{"reverse", "reverse_receiver"} {
+		t.Run(dir, func(t *testing.T) {
+			assertGolden(t, filepath.Join("testdata", dir, "structcopy-gen.go"))
+		})
+	}
+}
+
+func TestReverseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "reverse_conv", want: "reverse_conv.go:6:2: ReverseConvConverter.OrderToOrderDTO: reverse: conv of Total needs an inverse func"},
+		{name: "reverse_undeclared", want: "reverse_undeclared.go:6:2: ReverseUndeclaredConverter.InvoiceToInvoiceDTO: reverse method InvoiceDTOToInvoice must be declared in the interface with receiver_type s"},
+		{name: "reverse_signature", want: "reverse_signature.go:9:2: ReverseSignatureConverter.InvoiceDTOToInvoice: the reverse of InvoiceToInvoiceDTO must be func(src *InvoiceDTO) (dst *Invoice)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertError(t, filepath.Join("testdata", "errors", tt.name+".go"), tt.want)
+		})
+	}
+}
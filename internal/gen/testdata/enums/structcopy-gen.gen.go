@@ -0,0 +1,49 @@
+// Code generated by github.com/structcopy/structcopy-gen. DO NOT EDIT.
+
+package enums
+
+func StatusToCode(src Status) (dst StatusCode) {
+	switch src {
+	case StatusActive:
+		dst = StatusCodeActive
+	case StatusInactive:
+		dst = StatusCodeInactive
+	case StatusSuspended, StatusBanned:
+		dst = StatusCodeDisabled
+	}
+
+	return
+}
+
+func MemberToMemberDTO(src *Member) (dst *MemberDTO) {
+	dst = &MemberDTO{}
+	dst.Name = src.Name
+	dst.Status = StatusToCode(src.Status)
+	switch src.Level {
+	case LevelBasic:
+		dst.Plan = PlanBasic
+	case LevelPro:
+		dst.Plan = PlanPro
+	case LevelEnterprise:
+		dst.Plan = PlanEnterprise
+	default:
+		dst.Plan = PlanUnknown
+	}
+
+	return
+}
+
+func AccountToAccountDTO(src *Account) (dst *AccountDTO) {
+	dst = &AccountDTO{}
+	dst.Status = StatusToCode(src.Status)
+	switch src.Level {
+	case LevelBasic, LevelTrial:
+		dst.Level = PlanBasic
+	case LevelPro:
+		dst.Level = PlanPro
+	case LevelEnterprise:
+		dst.Level = PlanEnterprise
+	}
+
+	return
+}
@@ -0,0 +1,15 @@
+package enums
+
+// :structcopy-gen
+type EnumConverter interface {
+	// :enum_pair StatusCodeDisabled StatusSuspended
+	// :enum_pair StatusCodeDisabled StatusBanned
+	StatusToCode(src Status) StatusCode
+
+	// :enum_map Plan Level
+	// :enum_default PlanUnknown
+	MemberToMemberDTO(src *Member) (dst *MemberDTO)
+
+	// :enum_pair PlanBasic LevelTrial
+	AccountToAccountDTO(src *Account) (dst *AccountDTO)
+}
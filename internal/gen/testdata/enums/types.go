@@ -0,0 +1,58 @@
+package enums
+
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusInactive
+	StatusSuspended
+	StatusBanned
+)
+
+type StatusCode string
+
+const (
+	StatusCodeActive   StatusCode = "active"
+	StatusCodeInactive StatusCode = "inactive"
+	StatusCodeDisabled StatusCode = "disabled"
+)
+
+type Level int
+
+const (
+	LevelBasic Level = iota
+	LevelPro
+	LevelEnterprise
+	LevelTrial
+)
+
+type Plan string
+
+const (
+	PlanBasic      Plan = "basic"
+	PlanPro        Plan = "pro"
+	PlanEnterprise Plan = "enterprise"
+	PlanUnknown    Plan = "unknown"
+)
+
+type Member struct {
+	Name   string
+	Status Status
+	Level  Level
+}
+
+type MemberDTO struct {
+	Name   string
+	Status StatusCode
+	Plan   Plan
+}
+
+type Account struct {
+	Status Status
+	Level  Level
+}
+
+type AccountDTO struct {
+	Status StatusCode
+	Level  Plan
+}
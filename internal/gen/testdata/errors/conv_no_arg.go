@@ -0,0 +1,8 @@
+package errors
+
+// :structcopy-gen
+type ConvNoArgConverter interface {
+	// :skip_field Total
+	// :conv CreatedAt Now
+	OrderToOrderDTO(src *Order) (dst *OrderDTO)
+}
@@ -0,0 +1,7 @@
+package errors
+
+// :structcopy-gen
+type ConvParamConverter interface {
+	// :conv Total ParseAmount
+	InvoiceToInvoiceDTO(src *Invoice) (dst *InvoiceDTO)
+}
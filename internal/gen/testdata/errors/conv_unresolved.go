@@ -0,0 +1,7 @@
+package errors
+
+// :structcopy-gen
+type ConvUnresolvedConverter interface {
+	// :conv Name Strr
+	UserToUserDTO(src *User) (dst *UserDTO)
+}
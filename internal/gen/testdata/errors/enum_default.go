@@ -0,0 +1,8 @@
+package errors
+
+// :structcopy-gen
+type EnumDefaultConverter interface {
+	// :enum_pair StatusCodeDisabled StatusSuspended
+	// :enum_default StatusCodeUnknown
+	StatusToCode(src Status) StatusCode
+}
@@ -0,0 +1,8 @@
+package errors
+
+// :structcopy-gen
+// :enum_default StatusCodeUnknown
+type EnumInterfaceConverter interface {
+	// :enum_pair StatusCodeDisabled StatusSuspended
+	StatusToCode(src Status) StatusCode
+}
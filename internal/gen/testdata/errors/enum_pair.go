@@ -0,0 +1,8 @@
+package errors
+
+// :structcopy-gen
+type EnumPairConverter interface {
+	// :enum_pair StatusCodeDisabled StatusSuspended
+	// :enum_pair StatusCodeDisabled StatusBanned
+	StatusToCode(src Status) StatusCode
+}
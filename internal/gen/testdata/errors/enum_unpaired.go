@@ -0,0 +1,6 @@
+package errors
+
+// :structcopy-gen
+type EnumUnpairedConverter interface {
+	StatusToCode(src Status) StatusCode
+}
@@ -0,0 +1,8 @@
+package errors
+
+// :structcopy-gen
+type MergeStructConverter interface {
+	// :merge
+	// :conv Origin ToPointDTO
+	ApplyShape(dst *ShapeDTO, src *Shape)
+}
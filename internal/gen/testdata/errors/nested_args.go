@@ -0,0 +1,8 @@
+package errors
+
+// :structcopy-gen
+type NestedArgsConverter interface {
+	UserToUserDTO(src *User, country string) (dst *UserDTO)
+
+	AddressToAddressDTO(src *Address, country string, zone int) (dst *AddressDTO)
+}
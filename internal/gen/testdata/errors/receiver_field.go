@@ -0,0 +1,9 @@
+package errors
+
+// :structcopy-gen
+// :receiver_type s
+// :receiver_name receiverFieldConverter
+// :receiver_field clock
+type ReceiverFieldConverter interface {
+	UserToUserDTO(src *User) (dst *UserDTO)
+}
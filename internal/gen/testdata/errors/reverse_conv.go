@@ -0,0 +1,8 @@
+package errors
+
+// :structcopy-gen
+type ReverseConvConverter interface {
+	// :conv Total ParseAmount
+	// :reverse OrderDTOToOrder
+	OrderToOrderDTO(src *Order) (dst *OrderDTO, err error)
+}
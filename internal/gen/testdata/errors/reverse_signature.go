@@ -0,0 +1,10 @@
+package errors
+
+// :structcopy-gen
+// :receiver_type s
+type ReverseSignatureConverter interface {
+	// :reverse InvoiceDTOToInvoice
+	InvoiceToInvoiceDTO(src *Invoice) (dst *InvoiceDTO)
+
+	InvoiceDTOToInvoice(src InvoiceDTO) (dst *Invoice)
+}
@@ -0,0 +1,8 @@
+package errors
+
+// :structcopy-gen
+// :receiver_type s
+type ReverseUndeclaredConverter interface {
+	// :reverse InvoiceDTOToInvoice
+	InvoiceToInvoiceDTO(src *Invoice) (dst *InvoiceDTO)
+}
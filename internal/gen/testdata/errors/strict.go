@@ -0,0 +1,7 @@
+package errors
+
+// :structcopy-gen
+type StrictConverter interface {
+	// :strict
+	UserToUserDTO(src *User) (dst *UserDTO)
+}
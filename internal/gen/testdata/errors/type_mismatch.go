@@ -0,0 +1,6 @@
+package errors
+
+// :structcopy-gen
+type TypeMismatchConverter interface {
+	OrderToOrderDTO(src *Order) (dst *OrderDTO)
+}
@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"strconv"
+	"time"
+)
+
+type User struct {
+	Name    string
+	Email   string
+	Address *Address
+}
+
+type UserDTO struct {
+	Name    string
+	Email   string
+	Phone   string
+	Address *AddressDTO
+}
+
+type Address struct {
+	City string
+}
+
+type AddressDTO struct {
+	City string
+}
+
+type Order struct {
+	Total     string
+	CreatedAt time.Time
+}
+
+type OrderDTO struct {
+	Total     int64
+	CreatedAt time.Time
+}
+
+type Invoice struct {
+	Total int64
+}
+
+type InvoiceDTO struct {
+	Total int64
+}
+
+func Now() time.Time {
+	return time.Now()
+}
+
+func ParseAmount(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+type Clock interface {
+	Now() time.Time
+}
+
+type Point struct {
+	X, Y int
+}
+
+type Shape struct {
+	Origin Point
+}
+
+type PointDTO struct {
+	X, Y int
+}
+
+type ShapeDTO struct {
+	Origin PointDTO
+}
+
+func ToPointDTO(p Point) PointDTO {
+	return PointDTO{X: p.X, Y: p.Y}
+}
+
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusSuspended
+)
+
+type StatusCode string
+
+const (
+	StatusCodeActive   StatusCode = "active"
+	StatusCodeDisabled StatusCode = "disabled"
+)
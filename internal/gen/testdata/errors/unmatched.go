@@ -0,0 +1,6 @@
+package errors
+
+// :structcopy-gen
+type UnmatchedConverter interface {
+	UserToUserDTO(src *User) (dst *UserDTO)
+}
@@ -0,0 +1,96 @@
+// Code generated by github.com/structcopy/structcopy-gen. DO NOT EDIT.
+
+package merge
+
+func ApplyUserPatch(dst *User, src *UserPatch) {
+	if dst.Base == nil {
+		dst.Base = &Base{}
+	}
+	if src.ID != 0 {
+		dst.Base.ID = src.ID
+	}
+	// no match: dst.Base.Version
+	dst.Name = src.Name
+	if src.Email != nil {
+		dst.Email = *src.Email
+	}
+	if src.Age != nil {
+		dst.Age = *src.Age
+	}
+	if dst.Role == "" {
+		dst.Role = "member"
+	}
+	if !src.SyncedAt.IsZero() {
+		dst.SyncedAt = src.SyncedAt
+	}
+	if src.Address != nil {
+		if dst.Address == nil {
+			dst.Address = &Address{}
+		}
+		if src.Address.City != "" {
+			dst.Address.City = src.Address.City
+		}
+		if src.Address.Zip != "" {
+			dst.Address.Zip = src.Address.Zip
+		}
+	}
+	if src.Nicknames != nil {
+		dst.Nicknames = make([]string, len(src.Nicknames))
+		copy(dst.Nicknames, src.Nicknames)
+	}
+}
+
+func CopyUserPatch(dst *User, src *UserPatch) {
+	if dst.Base == nil {
+		dst.Base = &Base{}
+	}
+	dst.Base.ID = src.ID
+	// no match: dst.Base.Version
+	dst.Name = src.Name
+	if src.Email != nil {
+		dst.Email = *src.Email
+	}
+	if src.Age != nil {
+		dst.Age = *src.Age
+	}
+	if dst.Role == "" {
+		dst.Role = "member"
+	}
+	dst.SyncedAt = src.SyncedAt
+	if src.Address != nil {
+		dst.Address = &Address{}
+		dst.Address.City = src.Address.City
+		dst.Address.Zip = src.Address.Zip
+	}
+	if src.Nicknames != nil {
+		dst.Nicknames = make([]string, len(src.Nicknames))
+		copy(dst.Nicknames, src.Nicknames)
+	}
+}
+
+func UserPatchToUser(src *UserPatch) (dst *User) {
+	dst = &User{}
+	dst.Base = &Base{}
+	dst.Base.ID = src.ID
+	// no match: dst.Base.Version
+	dst.Name = src.Name
+	if src.Email != nil {
+		dst.Email = *src.Email
+	}
+	if src.Age != nil {
+		dst.Age = *src.Age
+	}
+	dst.Role = "member"
+	dst.SyncedAt = src.SyncedAt
+	if src.Address != nil {
+		dst.Address = &Address{}
+		dst.Address.City = src.Address.City
+		dst.Address.Zip = src.Address.Zip
+	}
+	if src.Nicknames != nil {
+		dst.Nicknames = make([]string, len(src.Nicknames))
+		copy(dst.Nicknames, src.Nicknames)
+	}
+
+	return
+}
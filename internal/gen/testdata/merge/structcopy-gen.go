@@ -0,0 +1,15 @@
+package merge
+
+// :structcopy-gen
+type Merger interface {
+	// :merge
+	// :merge_zero Name
+	// :default Role "member"
+	ApplyUserPatch(dst *User, src *UserPatch)
+
+	// :default Role "member"
+	CopyUserPatch(dst *User, src *UserPatch)
+
+	// :default Role "member"
+	UserPatchToUser(src *UserPatch) (dst *User)
+}
@@ -0,0 +1,39 @@
+package merge
+
+import "time"
+
+type Base struct {
+	ID      int64
+	Version int
+}
+
+type User struct {
+	*Base
+	Name      string
+	Email     string
+	Age       int
+	Role      string
+	SyncedAt  time.Time
+	Address   *Address
+	Nicknames []string
+}
+
+type Address struct {
+	City string
+	Zip  string
+}
+
+type UserPatch struct {
+	ID        int64
+	Name      string
+	Email     *string
+	Age       *int
+	SyncedAt  time.Time
+	Address   *AddressPatch
+	Nicknames []string
+}
+
+type AddressPatch struct {
+	City string
+	Zip  string
+}
@@ -0,0 +1,45 @@
+// Code generated by github.com/structcopy/structcopy-gen. DO NOT EDIT.
+
+package reverse
+
+func CustomerToCustomerDTO(src *Customer) (dst *CustomerDTO) {
+	dst = &CustomerDTO{}
+	dst.Name = src.Name
+
+	return
+}
+
+func OrderToOrderDTO(src *Order) (dst *OrderDTO) {
+	dst = &OrderDTO{}
+	dst.ID = 0
+	dst.Amount = FormatAmount(src.Total)
+	dst.Memo = src.Note
+	if src.Customer != nil {
+		dst.Customer = CustomerToCustomerDTO(src.Customer)
+	}
+
+	return
+}
+
+func CustomerDTOToCustomer(src *CustomerDTO) (dst *Customer) {
+	dst = &Customer{}
+	dst.Name = src.Name
+
+	return
+}
+
+func OrderDTOToOrder(src *OrderDTO) (dst *Order, err error) {
+	dst = &Order{}
+	dst.ID = src.ID
+	dst.Total, err = ParseAmount(src.Amount)
+	if err != nil {
+		return
+	}
+	dst.Note = src.Memo
+	// no match: dst.Internal
+	if src.Customer != nil {
+		dst.Customer = CustomerDTOToCustomer(src.Customer)
+	}
+
+	return
+}
@@ -0,0 +1,14 @@
+package reverse
+
+// :structcopy-gen
+type OrderConverter interface {
+	// :reverse CustomerDTOToCustomer
+	CustomerToCustomerDTO(src *Customer) (dst *CustomerDTO)
+
+	// :match_field Amount Total
+	// :match_field Memo Note
+	// :conv Amount FormatAmount ParseAmount
+	// :literal ID 0
+	// :reverse OrderDTOToOrder
+	OrderToOrderDTO(src *Order) (dst *OrderDTO)
+}
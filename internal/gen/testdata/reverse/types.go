@@ -0,0 +1,34 @@
+package reverse
+
+import "strconv"
+
+type Order struct {
+	ID       int64
+	Total    int64
+	Note     string
+	Internal string
+	Customer *Customer
+}
+
+type Customer struct {
+	Name string
+}
+
+type OrderDTO struct {
+	ID       int64
+	Amount   string
+	Memo     string
+	Customer *CustomerDTO
+}
+
+type CustomerDTO struct {
+	Name string
+}
+
+func FormatAmount(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+func ParseAmount(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
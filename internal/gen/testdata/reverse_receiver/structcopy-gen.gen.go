@@ -0,0 +1,52 @@
+// Code generated by github.com/structcopy/structcopy-gen. DO NOT EDIT.
+
+package reverse_receiver
+
+type myConverter struct {
+}
+
+func NewOrderConverter() OrderConverter {
+	return &myConverter{}
+}
+
+func (c *myConverter) CustomerToCustomerDTO(src *Customer) (dst *CustomerDTO) {
+	dst = &CustomerDTO{}
+	dst.Name = src.Name
+
+	return
+}
+
+func (c *myConverter) CustomerDTOToCustomer(src *CustomerDTO) (dst *Customer) {
+	dst = &Customer{}
+	dst.Name = src.Name
+
+	return
+}
+
+func (c *myConverter) OrderToOrderDTO(src *Order) (dst *OrderDTO) {
+	dst = &OrderDTO{}
+	dst.ID = src.ID
+	dst.Amount = FormatAmount(src.Total)
+	dst.Memo = src.Note
+	if src.Customer != nil {
+		dst.Customer = c.CustomerToCustomerDTO(src.Customer)
+	}
+
+	return
+}
+
+func (c *myConverter) OrderDTOToOrder(src *OrderDTO) (dst *Order, err error) {
+	dst = &Order{}
+	dst.ID = src.ID
+	dst.Total, err = ParseAmount(src.Amount)
+	if err != nil {
+		return
+	}
+	dst.Note = src.Memo
+	// skip: dst.Internal
+	if src.Customer != nil {
+		dst.Customer = c.CustomerDTOToCustomer(src.Customer)
+	}
+
+	return
+}
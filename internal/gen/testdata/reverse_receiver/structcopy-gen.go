@@ -0,0 +1,19 @@
+package reverse_receiver
+
+// :structcopy-gen
+// :receiver_type s
+type OrderConverter interface {
+	// :reverse CustomerDTOToCustomer
+	CustomerToCustomerDTO(src *Customer) (dst *CustomerDTO)
+
+	CustomerDTOToCustomer(src *CustomerDTO) (dst *Customer)
+
+	// :match_field Amount Total
+	// :match_field Memo Note
+	// :conv Amount FormatAmount ParseAmount
+	// :reverse OrderDTOToOrder
+	OrderToOrderDTO(src *Order) (dst *OrderDTO)
+
+	// :skip_field Internal
+	OrderDTOToOrder(src *OrderDTO) (dst *Order, err error)
+}
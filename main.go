@@ -12,6 +12,7 @@ import (
 	"github.com/structcopy/structcopy-gen/config"
 	"github.com/structcopy/structcopy-gen/internal/gen"
 	"github.com/structcopy/structcopy-gen/internal/load"
+	"github.com/structcopy/structcopy-gen/pkg/structcopy"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -23,6 +24,12 @@ type App struct {
 }
 
 func NewApp(cfg *config.AppConfig) (*App, error) {
+	if report := cfg.CliFlags.ReportUnusedSrc; report != "" {
+		if _, ok := structcopy.NewUnusedSrcReportFromValue(report); !ok {
+			return nil, fmt.Errorf("report-unused-src is invalid: %v", report)
+		}
+	}
+
 	return &App{
 		cfg:          cfg,
 		logEnabled:   cfg.CliFlags.LogEnabled,
@@ -50,6 +57,8 @@ func (a *App) Run() error {
 				file,
 				gen.WithInputPath(inp),
 				gen.WithOutputPath(out),
+				gen.WithStrict(a.cfg.CliFlags.Strict),
+				gen.WithReportUnusedSrc(structcopy.UnusedSrcReport(a.cfg.CliFlags.ReportUnusedSrc)),
 				gen.WithLogger(logger),
 			)
 			if err != nil {
@@ -105,6 +114,8 @@ func (a *App) Run() error {
 				file,
 				gen.WithInputPath(inp),
 				gen.WithOutputPath(out),
+				gen.WithStrict(a.cfg.CliFlags.Strict),
+				gen.WithReportUnusedSrc(structcopy.UnusedSrcReport(a.cfg.CliFlags.ReportUnusedSrc)),
 				gen.WithLogPath(log),
 				gen.WithLogEnabled(a.cfg.LogEnabled),
 			)
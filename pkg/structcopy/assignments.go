@@ -12,6 +12,60 @@ type Assignment interface {
 	RetError() bool
 }
 
+// errorCheck is the statement returning the error of a failed assignment.
+const errorCheck = "if err != nil {\nreturn\n}\n"
+
+// ReturnsError reports whether any of the assignments, including the ones
+// nested in structs and loops, can fail with an error the method must return.
+func ReturnsError(assignments []Assignment) bool {
+	for _, a := range assignments {
+		switch c := a.(type) {
+		case *NestStruct:
+			if ReturnsError(c.Contents) {
+				return true
+			}
+		case *MapConvertLoopAssignment:
+			if c.Error {
+				return true
+			}
+		case *SliceConvertLoopAssignment:
+			if c.Error {
+				return true
+			}
+		case *SliceStructConvertLoopAssignment:
+			if c.Error {
+				return true
+			}
+		case *SetterField:
+			if c.Error || ReturnsError(c.Contents) {
+				return true
+			}
+		default:
+			if a.RetError() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// UnmatchedFields returns the destination fields of the assignments, including
+// the ones nested in structs, which didn't match any source.
+func UnmatchedFields(assignments []Assignment) []string {
+	var fields []string
+	for _, a := range assignments {
+		switch c := a.(type) {
+		case *NoMatchField:
+			fields = append(fields, c.LHS)
+		case *NestStruct:
+			fields = append(fields, UnmatchedFields(c.Contents)...)
+		case *SetterField:
+			fields = append(fields, UnmatchedFields(c.Contents)...)
+		}
+	}
+	return fields
+}
+
 // SkipField indicates that the field is skipped due to a :skip notation.
 type SkipField struct {
 	LHS string // LHS is the left-hand side of the skipped field.
@@ -80,6 +134,7 @@ type ConvertField struct {
 	LHS     string
 	RHS     string
 	Convert string
+	Args    []string // Args holds the additional arguments passed to Convert.
 	Error   bool
 }
 
@@ -94,6 +149,10 @@ func (s ConvertField) String() string {
 	sb.WriteString(s.Convert)
 	sb.WriteString("(")
 	sb.WriteString(s.RHS)
+	for _, arg := range s.Args {
+		sb.WriteString(", ")
+		sb.WriteString(arg)
+	}
 	sb.WriteString(")")
 	sb.WriteString("\n")
 	return sb.String()
@@ -132,10 +191,91 @@ func (s MatchMethodField) RetError() bool {
 	return s.Error
 }
 
+// SetterField represents a field set by calling a setter of the destination.
+// The value is either the expression Value, or the variable Var of type Typ
+// filled by Contents in a block of its own.
+type SetterField struct {
+	Receiver string
+	Setter   string
+	Value    string
+	Var      string
+	Typ      string
+	Contents []Assignment
+	Error    bool // Error indicates that Setter returns an error.
+}
+
+// String returns the string representation of the setter call.
+func (s SetterField) String() string {
+	var sb strings.Builder
+	value := s.Value
+	if len(s.Contents) > 0 {
+		value = s.Var
+		sb.WriteString("{\nvar ")
+		sb.WriteString(s.Var)
+		sb.WriteString(" ")
+		sb.WriteString(s.Typ)
+		sb.WriteString("\n")
+		for _, content := range s.Contents {
+			sb.WriteString(content.String())
+			if content.RetError() {
+				sb.WriteString(errorCheck)
+			}
+		}
+	}
+	if s.Error {
+		sb.WriteString("err = ")
+	}
+	sb.WriteString(s.Receiver)
+	sb.WriteString(".")
+	sb.WriteString(s.Setter)
+	sb.WriteString("(")
+	sb.WriteString(value)
+	sb.WriteString(")\n")
+	if len(s.Contents) > 0 {
+		if s.Error {
+			sb.WriteString(errorCheck)
+		}
+		sb.WriteString("}\n")
+	}
+	return sb.String()
+}
+
+// RetError returns whether the setter returns an error, unless it's checked
+// within the block.
+func (s SetterField) RetError() bool {
+	return s.Error && len(s.Contents) == 0
+}
+
+// DefaultField represents the fallback value of a field left to its zero value.
+type DefaultField struct {
+	LHS      string
+	CondExpr string // CondExpr is the condition of the field being zero, e.g. `dst.Name == ""`.
+	Value    string
+}
+
+// String returns the string representation of the default field assignment.
+func (s DefaultField) String() string {
+	var sb strings.Builder
+	sb.WriteString("if ")
+	sb.WriteString(s.CondExpr)
+	sb.WriteString(" {\n")
+	sb.WriteString(s.LHS)
+	sb.WriteString(" = ")
+	sb.WriteString(s.Value)
+	sb.WriteString("\n}\n")
+	return sb.String()
+}
+
+// RetError always returns false for default field assignments.
+func (s DefaultField) RetError() bool {
+	return false
+}
+
 // NestStruct represents a struct in a struct.
 type NestStruct struct {
 	InitExpr      string
 	NullCheckExpr string
+	CondExpr      string // CondExpr is the condition of the block, e.g. `src.Name != ""`.
 	Contents      []Assignment
 }
 
@@ -146,6 +286,10 @@ func (s NestStruct) String() string {
 		sb.WriteString("if ")
 		sb.WriteString(s.NullCheckExpr)
 		sb.WriteString(" != nil {\n")
+	} else if s.CondExpr != "" {
+		sb.WriteString("if ")
+		sb.WriteString(s.CondExpr)
+		sb.WriteString(" {\n")
 	}
 	if s.InitExpr != "" {
 		sb.WriteString(s.InitExpr)
@@ -153,18 +297,104 @@ func (s NestStruct) String() string {
 	}
 	for _, content := range s.Contents {
 		sb.WriteString(content.String())
+		if content.RetError() {
+			sb.WriteString(errorCheck)
+		}
 	}
-	if s.NullCheckExpr != "" {
+	if s.NullCheckExpr != "" || s.CondExpr != "" {
 		sb.WriteString("}\n")
 	}
 	return sb.String()
 }
 
-// RetError returns whether the assignment returns an error value.
+// RetError always returns false as the errors of the contents are checked within the block.
 func (s NestStruct) RetError() bool {
 	return false
 }
 
+// MapAssignment represents a map assignment which copies the entries into a new map.
+type MapAssignment struct {
+	LHS string
+	RHS string
+	Typ string
+}
+
+// String returns the string representation of the map assignment.
+func (c MapAssignment) String() string {
+	var sb strings.Builder
+	sb.WriteString("if ")
+	sb.WriteString(c.RHS)
+	sb.WriteString(" != nil {\n")
+	sb.WriteString(c.LHS)
+	sb.WriteString(" = make(")
+	sb.WriteString(c.Typ)
+	sb.WriteString(", len(")
+	sb.WriteString(c.RHS)
+	sb.WriteString("))\nfor k, v := range ")
+	sb.WriteString(c.RHS)
+	sb.WriteString(" {\n")
+	sb.WriteString(c.LHS)
+	sb.WriteString("[k] = v\n}\n}\n")
+	return sb.String()
+}
+
+// RetError returns whether the assignment returns an error value.
+func (c MapAssignment) RetError() bool {
+	return false
+}
+
+// MapConvertLoopAssignment represents a map assignment which converts each value
+// into a new map.
+type MapConvertLoopAssignment struct {
+	LHS       string
+	RHS       string
+	Typ       string
+	ValueExpr string // ValueExpr is the expression converting the value "v".
+	NilValue  string // NilValue, if not empty, is stored for the nil values without conversion, e.g. "nil".
+	Error     bool   // Error indicates that ValueExpr also returns an error.
+}
+
+// String returns the string representation of the map assignment with a loop.
+func (c MapConvertLoopAssignment) String() string {
+	var sb strings.Builder
+	sb.WriteString("if ")
+	sb.WriteString(c.RHS)
+	sb.WriteString(" != nil {\n")
+	sb.WriteString(c.LHS)
+	sb.WriteString(" = make(")
+	sb.WriteString(c.Typ)
+	sb.WriteString(", len(")
+	sb.WriteString(c.RHS)
+	sb.WriteString("))\nfor k, v := range ")
+	sb.WriteString(c.RHS)
+	sb.WriteString(" {\n")
+	if c.NilValue != "" {
+		sb.WriteString("if v == nil {\n")
+		sb.WriteString(c.LHS)
+		sb.WriteString("[k] = ")
+		sb.WriteString(c.NilValue)
+		sb.WriteString("\ncontinue\n}\n")
+	}
+	sb.WriteString(c.LHS)
+	sb.WriteString("[k]")
+	if c.Error {
+		sb.WriteString(", err")
+	}
+	sb.WriteString(" = ")
+	sb.WriteString(c.ValueExpr)
+	sb.WriteString("\n")
+	if c.Error {
+		sb.WriteString(errorCheck)
+	}
+	sb.WriteString("}\n}\n")
+	return sb.String()
+}
+
+// RetError always returns false as the errors are checked within the loop.
+func (c MapConvertLoopAssignment) RetError() bool {
+	return false
+}
+
 // SliceAssignment represents a slice assignment.
 type SliceAssignment struct {
 	LHS string
@@ -261,13 +491,62 @@ func (c SliceTypecastAssignment) RetError() bool {
 	return false
 }
 
+// SliceConvertLoopAssignment represents a slice assignment which converts each element.
+type SliceConvertLoopAssignment struct {
+	LHS      string
+	RHS      string
+	Typ      string
+	ElemExpr string // ElemExpr is the expression converting the element "e".
+	NilCheck bool   // NilCheck indicates that nil elements are left nil without conversion.
+	Error    bool   // Error indicates that ElemExpr also returns an error.
+}
+
+// String returns the string representation of the slice assignment with a loop.
+func (c SliceConvertLoopAssignment) String() string {
+	var sb strings.Builder
+	sb.WriteString("if ")
+	sb.WriteString(c.RHS)
+	sb.WriteString(" != nil {\n")
+	sb.WriteString(c.LHS)
+	sb.WriteString(" = make(")
+	sb.WriteString(c.Typ)
+	sb.WriteString(", len(")
+	sb.WriteString(c.RHS)
+	sb.WriteString("))\nfor i, e := range ")
+	sb.WriteString(c.RHS)
+	sb.WriteString(" {\n")
+	if c.NilCheck {
+		sb.WriteString("if e == nil {\ncontinue\n}\n")
+	}
+	sb.WriteString(c.LHS)
+	sb.WriteString("[i]")
+	if c.Error {
+		sb.WriteString(", err")
+	}
+	sb.WriteString(" = ")
+	sb.WriteString(c.ElemExpr)
+	sb.WriteString("\n")
+	if c.Error {
+		sb.WriteString(errorCheck)
+	}
+	sb.WriteString("}\n}\n")
+	return sb.String()
+}
+
+// RetError always returns false as the errors are checked within the loop.
+func (c SliceConvertLoopAssignment) RetError() bool {
+	return false
+}
+
 // SliceStructConvertLoopAssignment represents a slice assignment with a loop and typecast.
 type SliceStructConvertLoopAssignment struct {
 	LHS           string
 	RHS           string
 	Typ           string
 	StructConvert string
+	Args          []string // Args holds the additional arguments passed to StructConvert.
 	ReceiverType  string
+	Error         bool // Error indicates that StructConvert also returns an error.
 }
 
 // String returns the string representation of the slice assignment with a loop.
@@ -286,16 +565,76 @@ func (c SliceStructConvertLoopAssignment) String() string {
 	sb.WriteString(c.RHS)
 	sb.WriteString("{\n")
 	sb.WriteString(c.LHS)
-	sb.WriteString("[i] = ")
+	sb.WriteString("[i]")
+	if c.Error {
+		sb.WriteString(", err")
+	}
+	sb.WriteString(" = ")
 	if c.ReceiverType == "s" {
 		sb.WriteString("c.")
 	}
 	sb.WriteString(c.StructConvert)
-	sb.WriteString("(e)\n}\n}\n")
+	sb.WriteString("(e")
+	for _, arg := range c.Args {
+		sb.WriteString(", ")
+		sb.WriteString(arg)
+	}
+	sb.WriteString(")\n")
+	if c.Error {
+		sb.WriteString(errorCheck)
+	}
+	sb.WriteString("}\n}\n")
 	return sb.String()
 }
 
-// RetError returns whether the assignment returns an error value.
+// RetError always returns false as the errors are checked within the loop.
 func (c SliceStructConvertLoopAssignment) RetError() bool {
 	return false
 }
+
+// EnumCase is a case of an EnumSwitchAssignment, mapping source constants to
+// a destination constant.
+type EnumCase struct {
+	Src []string // Src are the source constants, e.g. "entity.StatusActive".
+	Dst string   // Dst is the destination constant, e.g. "dto.StatusCodeActive".
+}
+
+// EnumSwitchAssignment represents a switch mapping the constants of an enum
+// type to the ones of another.
+type EnumSwitchAssignment struct {
+	LHS     string
+	RHS     string
+	Cases   []EnumCase
+	Default string // Default is assigned to LHS for the other values, if not empty.
+}
+
+// String returns the string representation of the enum switch assignment.
+func (c EnumSwitchAssignment) String() string {
+	var sb strings.Builder
+	sb.WriteString("switch ")
+	sb.WriteString(c.RHS)
+	sb.WriteString(" {\n")
+	for _, enumCase := range c.Cases {
+		sb.WriteString("case ")
+		sb.WriteString(strings.Join(enumCase.Src, ", "))
+		sb.WriteString(":\n")
+		sb.WriteString(c.LHS)
+		sb.WriteString(" = ")
+		sb.WriteString(enumCase.Dst)
+		sb.WriteString("\n")
+	}
+	if c.Default != "" {
+		sb.WriteString("default:\n")
+		sb.WriteString(c.LHS)
+		sb.WriteString(" = ")
+		sb.WriteString(c.Default)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// RetError always returns false for enum switch assignments.
+func (c EnumSwitchAssignment) RetError() bool {
+	return false
+}
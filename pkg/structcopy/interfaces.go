@@ -6,4 +6,14 @@ type Interface struct {
 	Methods      []Method
 	ReceiverType string
 	ReceiverName string
+	// ReceiverFields are the fields of the receiver struct, given to its
+	// constructor, with receiver type "s".
+	ReceiverFields []ReceiverField
+}
+
+// ReceiverField is a field of the receiver struct, e.g. a collaborator of the
+// converters such as a clock.
+type ReceiverField struct {
+	Name string
+	Typ  string // Typ is the type expression, as written in the notation
 }
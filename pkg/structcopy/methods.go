@@ -21,7 +21,25 @@ type Method struct {
 	SkipFieldsMap       map[string]bool
 	MatchFieldsMap      map[string]string
 	MatchMethodsMap     map[string]string
+	MatchSettersMap     map[string]string
 	ConvertersMap       map[string]string
+	LiteralsMap         map[string]string
+	DefaultsMap         map[string]string
+	NoPromoteMap        map[string]bool
+	MergeZeroMap        map[string]bool   // MergeZeroMap holds the fields copied even when their source is zero in merge mode
+	EnumMapsMap         map[string]string // EnumMapsMap holds the source field of the destination enum fields
+	EnumPairs           []EnumPair        // EnumPairs holds the source constants paired with destination constants
+	EnumDefault         string            // EnumDefault is the destination constant of the unmapped source constants
+	MatchRule           MatchRule
+	MatchTagKey         string // struct tag key used by MatchRuleTag
+	NameMatch           NameMatch
+	StripPrefixes       []string
+	StripSuffixes       []string
+	UseGetters          bool
+	UseSetters          bool
+	Merge               bool // Merge copies only the source values which are set
+	Strict              bool // Strict fails the generation on unmatched destination fields
+	ReportUnusedSrc     UnusedSrcReport
 	StructConverterFunc string
 	Assignments         []Assignment
 	PreProcess          *Manipulator
@@ -32,6 +50,15 @@ type Method struct {
 
 	DstVarStyle DstVarStyle
 	RetError    bool
+	// InitAssigned assigns the function variable of receiver type "f" in
+	// init(), to break the initialization cycles of recursive conversions.
+	InitAssigned bool
+	// HandWritten is set when the method is already declared on the receiver
+	// struct of receiver type "s", so it isn't generated.
+	HandWritten bool
+	// EnumMap is set when the method maps the constants of its source enum
+	// type to the ones of its destination enum type.
+	EnumMap bool
 }
 
 // DstVarStyle represents the style of destination variable in a function signature.
@@ -95,37 +122,112 @@ func NewMatchRuleFromValue(v string) (MatchRule, bool) {
 	return "", false
 }
 
-func (f Method) String() string {
-	var sb strings.Builder
+// UnusedSrcReport represents how the source fields left unused by a method are reported.
+type UnusedSrcReport string
 
-	// doc comment
-	for i := range f.Comments {
-		sb.WriteString(f.Comments[i])
-		sb.WriteString("\n")
+// String returns the string representation of the unused source fields report.
+func (s UnusedSrcReport) String() string {
+	return string(s)
+}
+
+const (
+	// UnusedSrcReportWarn indicates that the unused source fields are logged as a warning.
+	UnusedSrcReportWarn = UnusedSrcReport("warn")
+	// UnusedSrcReportError indicates that the unused source fields fail the generation.
+	UnusedSrcReportError = UnusedSrcReport("error")
+)
+
+// UnusedSrcReportValues is a slice of all possible unused source fields reports.
+var UnusedSrcReportValues = []UnusedSrcReport{UnusedSrcReportWarn, UnusedSrcReportError}
+
+// NewUnusedSrcReportFromValue creates a new UnusedSrcReport instance from the given value string.
+func NewUnusedSrcReportFromValue(v string) (UnusedSrcReport, bool) {
+	for _, report := range UnusedSrcReportValues {
+		if report.String() == v {
+			return report, true
+		}
 	}
+	return "", false
+}
 
-	// "func"
-	sb.WriteString("func ")
+// NameMatch represents the strategy to compare field names.
+type NameMatch string
 
-	if f.ReceiverType == "s" {
-		sb.WriteString("(c *")
-		sb.WriteString(f.ReceiverName)
-		sb.WriteString(") ")
+// String returns the string representation of the name match strategy.
+func (s NameMatch) String() string {
+	return string(s)
+}
+
+const (
+	// NameMatchExact indicates that field names must be identical.
+	NameMatchExact = NameMatch("exact")
+	// NameMatchIgnoreCase indicates that field names are compared ignoring case.
+	NameMatchIgnoreCase = NameMatch("ignore_case")
+	// NameMatchAcronym indicates that acronyms are normalized before comparing
+	// field names, e.g. "UserID" matches "UserId".
+	NameMatchAcronym = NameMatch("acronym")
+	// NameMatchSnakeCamel indicates that field names are compared by their
+	// words regardless of snake or camel case, e.g. "User_ID" matches "UserID".
+	NameMatchSnakeCamel = NameMatch("snake_camel")
+)
+
+// NameMatchValues is a slice of all possible name match strategies.
+var NameMatchValues = []NameMatch{NameMatchExact, NameMatchIgnoreCase, NameMatchAcronym, NameMatchSnakeCamel}
+
+// NewNameMatchFromValue creates a new NameMatch instance from the given value string.
+func NewNameMatchFromValue(v string) (NameMatch, bool) {
+	for _, s := range NameMatchValues {
+		if s.String() == v {
+			return s, true
+		}
 	}
+	return "", false
+}
 
-	// "func (r *SrcModel) Name("
-	sb.WriteString(f.Name)
-	sb.WriteString("(")
+// funcHead returns the declaration of the method up to its parameters, e.g.
+// "func (c *myConverter) Name", or "var Name = func" with receiver type "f".
+func (f Method) funcHead() string {
+	switch {
+	case f.ReceiverType == "s":
+		return "func (c *" + f.ReceiverName + ") " + f.Name
+	case f.ReceiverType == "f" && f.InitAssigned:
+		return f.Name + " = func"
+	case f.ReceiverType == "f":
+		return "var " + f.Name + " = func"
+	}
+	return "func " + f.Name
+}
 
+// FuncVarDecl returns the declaration of the function variable of the method
+// with receiver type "f", which is assigned in init().
+func (f Method) FuncVarDecl() string {
+	elemPrefix := ""
+	if f.FirstParam.IsSlice {
+		elemPrefix = "[]"
+	}
+	return "var " + f.Name + " func" + f.signature(elemPrefix) + "\n"
+}
+
+// signature returns the parameters and the results of the method, e.g.
+// "(src *SrcModel) (dst *DstModel, err error)". elemPrefix is prepended to the
+// types of the source and the destination, e.g. "[]" for slices.
+func (f Method) signature(elemPrefix string) string {
+	var sb strings.Builder
+
+	sb.WriteString("(")
 	if f.Receiver == "" {
-		// // "func Name(dst *DstModel, src *SrcModel"
-		// sb.WriteString(f.Src.Name)
-		// sb.WriteString(" ")
-		// sb.WriteString(f.Src.FullType())
+		if f.DstVarStyle == DstVarArg {
+			// "(dst *DstModel, "
+			sb.WriteString(f.FirstResult.Name)
+			sb.WriteString(" ")
+			sb.WriteString(f.FirstResult.FullType)
+			sb.WriteString(", ")
+		}
 
-		// "func Name(dst *DstModel, src *SrcModel"
+		// "(dst *DstModel, src *SrcModel"
 		sb.WriteString(f.FirstParam.Name)
 		sb.WriteString(" ")
+		sb.WriteString(elemPrefix)
 		sb.WriteString(f.FirstParam.FullType)
 	}
 
@@ -140,46 +242,57 @@ func (f Method) String() string {
 		sb.WriteString(" ")
 		sb.WriteString(fullType)
 	}
-
-	// "func Name(dst *DstModel, src *SrcModel)"
-	sb.WriteString(") ")
+	sb.WriteString(")")
 
 	if f.DstVarStyle == DstVarReturn {
-		// "func Name(src *SrcModel) (dst *DstModel"
-		sb.WriteString("(")
+		// "(src *SrcModel) (dst *DstModel, err error)"
+		sb.WriteString(" (")
 		sb.WriteString(f.FirstResult.Name)
 		sb.WriteString(" ")
+		sb.WriteString(elemPrefix)
 		sb.WriteString(f.FirstResult.FullType)
 		if f.RetError {
-			// "func Name(src *SrcModel) (dst *DstModel, err error"
 			sb.WriteString(", err error")
 		}
+		sb.WriteString(")")
+	} else if f.RetError {
+		// "(dst *DstModel, src *SrcModel) (err error)"
+		sb.WriteString(" (err error)")
+	}
 
-		// "func Name(src *SrcModel) (dst *DstModel) {"
-		sb.WriteString(") {\n")
-		if f.FirstResult.IsPointer {
-			// "dst = &DstModel{}"
-			sb.WriteString(f.FirstResult.Name)
-			sb.WriteString(" = ")
-			if f.FirstResult.IsPointer {
-				sb.WriteString("&")
-			}
-			sb.WriteString(f.FirstResult.PointerlessFullType)
-			sb.WriteString("{}\n")
-		}
-	} else {
-		if f.RetError {
-			// "func Name(dst *DstModel, src *SrcModel) (err error) {"
-			sb.WriteString("(err error) {\n")
-		} else {
-			// "func Name(dst *DstModel, src *SrcModel) {"
-			sb.WriteString("{\n")
-		}
+	return sb.String()
+}
+
+func (f Method) String() string {
+	var sb strings.Builder
+
+	// doc comment
+	for i := range f.Comments {
+		sb.WriteString(f.Comments[i])
+		sb.WriteString("\n")
+	}
+
+	// "func Name(src *SrcModel) (dst *DstModel) {"
+	sb.WriteString(f.funcHead())
+	sb.WriteString(f.signature(""))
+	sb.WriteString(" {\n")
+	if f.DstVarStyle == DstVarReturn && f.FirstResult.IsPointer {
+		// "dst = &DstModel{}"
+		sb.WriteString(f.FirstResult.Name)
+		sb.WriteString(" = &")
+		sb.WriteString(f.FirstResult.PointerlessFullType)
+		sb.WriteString("{}\n")
 	}
 
+	if f.PreProcess != nil {
+		sb.WriteString(f.ManipulatorToString(f.PreProcess, f.srcVariable(), f.dstVariable(), f.AdditionalArgs))
+	}
 	for i := range f.Assignments {
 		sb.WriteString(f.AssignmentToString(f.Assignments[i]))
 	}
+	if f.PostProcess != nil {
+		sb.WriteString(f.ManipulatorToString(f.PostProcess, f.srcVariable(), f.dstVariable(), f.AdditionalArgs))
+	}
 	if f.RetError || f.DstVarStyle == DstVarReturn {
 		sb.WriteString("\nreturn\n")
 	}
@@ -196,82 +309,20 @@ func (f Method) FormatSliceOfStruct() string {
 		sb.WriteString("\n")
 	}
 
-	// "func"
-	sb.WriteString("func ")
-
-	if f.ReceiverType == "s" {
-		sb.WriteString("(c *")
-		sb.WriteString(f.ReceiverName)
-		sb.WriteString(") ")
-	}
-
-	// "func (r *SrcModel) Name("
-	sb.WriteString(f.Name)
-	sb.WriteString("(")
-
-	if f.Receiver == "" {
-		// // "func Name(dst *DstModel, src *SrcModel"
-		// sb.WriteString(f.Src.Name)
-		// sb.WriteString(" ")
-		// sb.WriteString(f.Src.FullType())
+	// "func Name(src *SrcModel) (dst *DstModel) {"
+	sb.WriteString(f.funcHead())
+	sb.WriteString(f.signature("[]"))
+	sb.WriteString(" {\n")
 
-		// "func Name(dst *DstModel, src *SrcModel"
-		sb.WriteString(f.FirstParam.Name)
-		sb.WriteString(" []")
-		sb.WriteString(f.FirstParam.FullType)
+	if f.PreProcess != nil {
+		sb.WriteString(f.ManipulatorToString(f.PreProcess, f.srcVariable(), f.dstVariable(), f.AdditionalArgs))
 	}
-
-	for _, args := range f.AdditionalArgs {
-		fullType := args.FullType()
-		if strings.Contains(args.Type, "/") {
-			re := regexp.MustCompile(`^([^a-zA-Z0-9]*)([a-zA-Z0-9].*/)(.+)$`)
-			fullType = re.ReplaceAllString(fullType, "$1$3")
-		}
-		sb.WriteString(", ")
-		sb.WriteString(args.Name)
-		sb.WriteString(" ")
-		sb.WriteString(fullType)
-	}
-
-	// "func Name(dst *DstModel, src *SrcModel)"
-	sb.WriteString(") ")
-
-	if f.DstVarStyle == DstVarReturn {
-		// "func Name(src *SrcModel) (dst *DstModel"
-		sb.WriteString("(")
-		sb.WriteString(f.FirstResult.Name)
-		sb.WriteString(" []")
-		sb.WriteString(f.FirstResult.FullType)
-		if f.RetError {
-			// "func Name(src *SrcModel) (dst *DstModel, err error"
-			sb.WriteString(", err error")
-		}
-
-		// "func Name(src *SrcModel) (dst *DstModel) {"
-		sb.WriteString(") {\n")
-		// if f.NewFirstResult.IsPointer {
-		// 	// "dst = &DstModel{}"
-		// 	sb.WriteString(f.NewFirstResult.Name)
-		// 	sb.WriteString(" = ")
-		// 	if f.NewFirstResult.IsPointer {
-		// 		sb.WriteString("&")
-		// 	}
-		// 	sb.WriteString(f.NewFirstResult.PointerlessFullType)
-		// 	sb.WriteString("{}\n")
-		// }
-	} else {
-		if f.RetError {
-			// "func Name(dst *DstModel, src *SrcModel) (err error) {"
-			sb.WriteString("(err error) {\n")
-		} else {
-			// "func Name(dst *DstModel, src *SrcModel) {"
-			sb.WriteString("{\n")
-		}
-	}
-
 	for i := range f.Assignments {
 		sb.WriteString(f.AssignmentToString(f.Assignments[i]))
 	}
+	if f.PostProcess != nil {
+		sb.WriteString(f.ManipulatorToString(f.PostProcess, f.srcVariable(), f.dstVariable(), f.AdditionalArgs))
+	}
 
 	if f.RetError || f.DstVarStyle == DstVarReturn {
 		sb.WriteString("\nreturn\n")
@@ -280,12 +331,30 @@ func (f Method) FormatSliceOfStruct() string {
 	return sb.String()
 }
 
+// srcVariable returns the source variable of the method.
+func (f Method) srcVariable() Variable {
+	return Variable{
+		Name:    f.FirstParam.Name,
+		Type:    f.FirstParam.PointerlessFullType,
+		Pointer: f.FirstParam.IsPointer && !f.FirstParam.IsSlice,
+	}
+}
+
+// dstVariable returns the destination variable of the method.
+func (f Method) dstVariable() Variable {
+	return Variable{
+		Name:    f.FirstResult.Name,
+		Type:    f.FirstResult.PointerlessFullType,
+		Pointer: f.FirstResult.IsPointer && !f.FirstResult.IsSlice,
+	}
+}
+
 // AssignmentToString returns the string representation of the assignment.
 func (f Method) AssignmentToString(a Assignment) string {
 	var sb strings.Builder
 	sb.WriteString(a.String())
 	if a.RetError() {
-		sb.WriteString("if err != nil {\nreturn\n}\n")
+		sb.WriteString(errorCheck)
 	}
 	return sb.String()
 }
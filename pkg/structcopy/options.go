@@ -4,12 +4,48 @@ type InterfaceOption struct {
 	IsStructCopyGen bool
 	ReceiverType    string // n, s, f
 	ReceiverName    string // default: myConverter
+	ReceiverFields  []ReceiverField
 }
 
 type InputOption struct {
-	SkipFieldsMap       map[string]bool
-	MatchFieldsMap      map[string]string
-	MatchMethodsMap     map[string]string
-	ConvertersMap       map[string]string
-	StructConverterFunc string
+	SkipFieldsMap        map[string]bool
+	MatchFieldsMap       map[string]string
+	MatchMethodsMap      map[string]string
+	MatchSettersMap      map[string]string
+	ConvertersMap        map[string]string
+	InverseConvertersMap map[string]string // InverseConvertersMap holds the inverse of the converters, used by ReverseMethod
+	LiteralsMap          map[string]string
+	DefaultsMap          map[string]string
+	NoPromoteMap         map[string]bool
+	MergeZeroMap         map[string]bool
+	EnumMapsMap          map[string]string // EnumMapsMap holds the source field of the destination enum fields
+	EnumPairs            []EnumPair        // EnumPairs holds the source constants paired with destination constants
+	StructConverterFunc  string
+	PreProcessFunc       string
+	PostProcessFunc      string
+	EnumDefault          string    // EnumDefault is the destination constant of the unmapped source constants
+	ReverseMethod        string    // ReverseMethod is the name of the inverse method to generate
+	MatchRule            MatchRule // "" to inherit the interface's rule
+	MatchTagKey          string
+	NameMatch            NameMatch // "" to inherit the interface's strategy
+	StripPrefixes        []string
+	StripSuffixes        []string
+	UseGetters           bool
+	UseSetters           bool
+	Strict               bool
+	Merge                bool
+	ReportUnusedSrc      UnusedSrcReport // "" to inherit the interface's report
+}
+
+// EnumPair pairs a source constant with a destination constant, given by
+// ":enum_pair <dst_const> <src_const>". It applies to the enum types declaring
+// both constants.
+type EnumPair struct {
+	Dst string
+	Src string
+}
+
+// String returns the notation of the pair.
+func (p EnumPair) String() string {
+	return ":enum_pair " + p.Dst + " " + p.Src
 }
@@ -1,5 +1,7 @@
 package structcopy
 
+import "go/types"
+
 // Spec is the root structure to hold all extracted interfaces from a file.
 type Spec struct {
 	PackageName string
@@ -33,13 +35,24 @@ type TypeInfo struct {
 // Field represents info about a struct field
 type Field struct {
 	Name       string // field name
+	Path       string // selector path from the struct, e.g. "Base.ID" for a promoted field
 	Kind       string
 	Type       string // raw type name (UserID, *User, etc.)
 	FullType   string
 	IsStruct   bool
-	IsPointer  bool   // true if field type is pointer
-	IsSlice    bool   // true if field type is slice []User, []*User
-	PackageRef string // package import path if external type ("" if local)
+	IsPointer  bool       // true if field type is pointer
+	IsSlice    bool       // true if field type is slice []User, []*User
+	PackageRef string     // package name used in the generated code ("" if local)
+	Exported   bool       // true if the field is accessible from the generated package
+	Embedded   bool       // true if the field is an embedded field
+	Typ        types.Type // resolved type of the field
+	Tag        string     // raw struct tag of the field
+
+	// EmbeddedPtrs holds the selector paths of the pointer embedded fields
+	// this field is promoted through, e.g. "Base" for a field promoted from
+	// an embedded *Base, or of the pointer fields along a dotted source path,
+	// e.g. "Address" for "Address.City".
+	EmbeddedPtrs []string
 }
 
 type MethodParam struct {
@@ -52,7 +65,8 @@ type MethodParam struct {
 	IsStruct            bool
 	IsPointer           bool
 	IsSlice             bool
-	StructDef           *Struct // ParsedStruct if we found its definition
+	StructDef           *Struct    // ParsedStruct if we found its definition
+	Typ                 types.Type // resolved type, including slice and pointer
 }
 
 type MethodResult struct {
@@ -65,7 +79,8 @@ type MethodResult struct {
 	IsStruct            bool
 	IsPointer           bool
 	IsSlice             bool
-	StructDef           *Struct // ParsedStruct if we found its definition
+	StructDef           *Struct    // ParsedStruct if we found its definition
+	Typ                 types.Type // resolved type, including slice and pointer
 }
 
 type ParsedMethod struct {
@@ -1,9 +1,13 @@
 package structcopy
 
+import "go/types"
+
+// Struct represents a struct type resolved from the type information.
 type Struct struct {
-	PackagePath string // where this struct is defined ("" for local)
-	PkgName     string
-	Name        string // struct name
-	Type        string
-	Fields      []Field // struct fields
+	PackagePath string     // full import path of the package where this struct is defined
+	PkgName     string     // package name used in the generated code ("" for local)
+	Name        string     // struct name
+	Type        string     // type expression used in the generated code
+	Fields      []Field    // struct fields
+	Typ         types.Type // resolved struct type
 }